@@ -23,7 +23,12 @@ type Client struct {
 
 	PortGroupName string
 
+	// VolumeSnapshotMode is mode of CreateVolumeSnapshot, default is VolumeSnapshotBothSides.
+	VolumeSnapshotMode VolumeSnapshotMode
+
 	Logger *log.Logger
+
+	degraded *degradedState
 }
 
 // Device is device of dorado
@@ -142,9 +147,13 @@ func newDevice(ips []string, username, password string, httpClient *http.Client,
 		return nil, fmt.Errorf("failed to create cookiejar: %w", err)
 	}
 
+	// each device has own http.Client so that cookies never cross between devices
+	client := *httpClient
+	client.Jar = jar
+
 	d := &Device{
 		Controllers: parsedURLs,
-		HTTPClient:  httpClient,
+		HTTPClient:  &client,
 		Username:    username,
 		Password:    password,
 		Jar:         jar,
@@ -180,7 +189,6 @@ func (d *Device) newRequest(ctx context.Context, method, spath string, body io.R
 	req.Header.Set("User-Agent", userAgent)
 
 	req.Header.Set("iBaseToken", d.Token)
-	d.HTTPClient.Jar = d.Jar
 
 	return req, nil
 }
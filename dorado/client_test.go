@@ -43,6 +43,23 @@ func setup() (client *Client, mux *http.ServeMux, serverURL string, teardown fun
 	return client, mux, serverURL, server.Close
 }
 
+// setupRemote split RemoteDevice of client to other server, so that tests can handle each device.
+func setupRemote(client *Client) (remoteMux *http.ServeMux, teardown func()) {
+	remoteMux = http.NewServeMux()
+
+	apiHandler := http.NewServeMux()
+	apiHandler.Handle(baseURLTestPath+"/", http.StripPrefix(baseURLTestPath, remoteMux))
+
+	server := httptest.NewServer(apiHandler)
+
+	err := client.RemoteDevice.setBaseURL(server.URL, DefaultDeviceID)
+	if err != nil {
+		log.Fatalf("failed to set baseURL in remote devive: %s", err)
+	}
+
+	return remoteMux, server.Close
+}
+
 func testMethod(t *testing.T, r *http.Request, want string) {
 	t.Helper()
 	if got := r.Method; got != want {
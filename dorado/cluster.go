@@ -0,0 +1,439 @@
+package dorado
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// Cluster is set of objects for multi-host attachment.
+// all hosts in a cluster are associated to one hostgroup, and all volumes are associated to one lungroup.
+// so hosts in cluster see the same LUN in the same host LUN ID.
+type Cluster struct {
+	Name        string
+	HostGroup   *HostGroup
+	LunGroup    *LunGroup
+	MappingView *MappingView
+}
+
+// PrefixClusterName is prefix of objects name for cluster.
+// hostgroup, lungroup and mappingview for cluster is not conflicted with objects for single host.
+var PrefixClusterName = "cluster-"
+
+func clusterObjectName(clusterName string) string {
+	return PrefixClusterName + clusterName
+}
+
+// GetCluster get objects of cluster.
+// NAME filter of REST API match partially (e.g. "cluster-a" match "cluster-a1"),
+// so objects that have other cluster name are ignored.
+func (d *Device) GetCluster(ctx context.Context, clusterName string) (*Cluster, error) {
+	name := clusterObjectName(clusterName)
+
+	hostgroups, err := d.GetHostGroups(ctx, NewSearchQueryHostname(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hostgroup: %w", err)
+	}
+	hostgroups = clusterHostGroups(hostgroups, name)
+	if len(hostgroups) == 0 {
+		return nil, fmt.Errorf("failed to get hostgroup: %w", ErrHostGroupNotFound)
+	}
+	if len(hostgroups) > 1 {
+		return nil, fmt.Errorf("found multiple hostgroup in same cluster name (cluster: %s)", clusterName)
+	}
+	lungroups, err := d.GetLunGroups(ctx, NewSearchQueryHostname(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lungroup: %w", err)
+	}
+	lungroups = clusterLunGroups(lungroups, name)
+	if len(lungroups) == 0 {
+		return nil, fmt.Errorf("failed to get lungroup: %w", ErrLunGroupNotFound)
+	}
+	if len(lungroups) > 1 {
+		return nil, fmt.Errorf("found multiple lungroup in same cluster name (cluster: %s)", clusterName)
+	}
+	mappingviews, err := d.GetMappingViews(ctx, NewSearchQueryHostname(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mapping view: %w", err)
+	}
+	mappingviews = clusterMappingViews(mappingviews, name)
+	if len(mappingviews) == 0 {
+		return nil, fmt.Errorf("failed to get mapping view: %w", ErrMappingViewNotFound)
+	}
+	if len(mappingviews) > 1 {
+		return nil, fmt.Errorf("found multiple mapping view in same cluster name (cluster: %s)", clusterName)
+	}
+
+	return &Cluster{
+		Name:        clusterName,
+		HostGroup:   &hostgroups[0],
+		LunGroup:    &lungroups[0],
+		MappingView: &mappingviews[0],
+	}, nil
+}
+
+// clusterHostGroups return hostgroups that have exactly name of cluster object.
+func clusterHostGroups(hostgroups []HostGroup, name string) []HostGroup {
+	var matched []HostGroup
+	for _, hostgroup := range hostgroups {
+		if hostgroup.NAME == encodeHostName(name) {
+			matched = append(matched, hostgroup)
+		}
+	}
+	return matched
+}
+
+// clusterLunGroups return lungroups that have exactly name of cluster object.
+func clusterLunGroups(lungroups []LunGroup, name string) []LunGroup {
+	var matched []LunGroup
+	for _, lungroup := range lungroups {
+		if lungroup.NAME == encodeHostName(name) {
+			matched = append(matched, lungroup)
+		}
+	}
+	return matched
+}
+
+// clusterMappingViews return mapping views that have exactly name of cluster object.
+func clusterMappingViews(mappingviews []MappingView, name string) []MappingView {
+	var matched []MappingView
+	for _, mappingview := range mappingviews {
+		if mappingview.NAME == encodeHostName(name) {
+			matched = append(matched, mappingview)
+		}
+	}
+	return matched
+}
+
+// GetClusterForce get objects of cluster, and create objects if not exists.
+// created objects are mapped to port group.
+func (d *Device) GetClusterForce(ctx context.Context, portgroupName, clusterName string) (*Cluster, error) {
+	name := clusterObjectName(clusterName)
+
+	portgroup, err := d.GetPortGroupByName(ctx, portgroupName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portgroup: %w", err)
+	}
+
+	var hostgroup *HostGroup
+	hostgroups, err := d.GetHostGroups(ctx, NewSearchQueryHostname(name))
+	hostgroups = clusterHostGroups(hostgroups, name)
+	switch {
+	case err == ErrHostGroupNotFound, err == nil && len(hostgroups) == 0:
+		hostgroup, err = d.CreateHostGroup(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create hostgroup: %w", err)
+		}
+	case err != nil:
+		return nil, fmt.Errorf("failed to get hostgroup: %w", err)
+	case len(hostgroups) > 1:
+		return nil, fmt.Errorf("found multiple hostgroup in same cluster name (cluster: %s)", clusterName)
+	default:
+		hostgroup = &hostgroups[0]
+	}
+
+	lungroup, err := d.GetLunGroupForce(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lungroup: %w", err)
+	}
+	mappingview, err := d.GetMappingViewForce(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mappingview: %w", err)
+	}
+
+	err = d.DoMapping(ctx, mappingview, hostgroup, lungroup, portgroup.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to associate object to mappingview: %w", err)
+	}
+
+	return &Cluster{
+		Name:        clusterName,
+		HostGroup:   hostgroup,
+		LunGroup:    lungroup,
+		MappingView: mappingview,
+	}, nil
+}
+
+// GetClusterNodes get hosts in cluster.
+func (d *Device) GetClusterNodes(ctx context.Context, clusterName string) ([]Host, error) {
+	cluster, err := d.GetCluster(ctx, clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster: %w", err)
+	}
+
+	return d.GetHostGroupAssociatedHosts(ctx, cluster.HostGroup.ID)
+}
+
+// AddClusterNode add host to cluster. host and initiator is created if not exists.
+// a host can belong to only one hostgroup, so host that attached by AttachVolume can not add to cluster.
+// CHAP and Host in opts are used if set.
+func (d *Device) AddClusterNode(ctx context.Context, portgroupName, clusterName, hostname, iqn string, opts *AttachVolumeOption) (*Host, error) {
+	if opts == nil {
+		opts = &AttachVolumeOption{}
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	cluster, err := d.GetClusterForce(ctx, portgroupName, clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster: %w", err)
+	}
+
+	host, err := d.getHostForce(ctx, hostname, opts.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get host: %w", err)
+	}
+
+	if host.ISADD2HOSTGROUP == true {
+		if host.PARENTID != strconv.Itoa(cluster.HostGroup.ID) {
+			return nil, fmt.Errorf("host is already associated other hostgroup (hostname: %s, hostgroup: %s)", hostname, host.PARENTNAME)
+		}
+	} else {
+		err = d.AssociateHost(ctx, cluster.HostGroup.ID, host.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to associate host to hostgroup: %w", err)
+		}
+	}
+
+	if err := d.setupInitiator(ctx, iqn, host.ID, opts); err != nil {
+		return nil, err
+	}
+
+	return host, nil
+}
+
+// getHostForce get host object, and create host if not exists.
+func (d *Device) getHostForce(ctx context.Context, hostname string, opts *HostOption) (*Host, error) {
+	hosts, err := d.GetHosts(ctx, NewSearchQueryHostname(hostname))
+	if err != nil {
+		if err == ErrHostNotFound {
+			return d.CreateHostWithOption(ctx, hostname, opts)
+		}
+
+		return nil, fmt.Errorf("failed to get host: %w", err)
+	}
+	if len(hosts) != 1 {
+		return nil, fmt.Errorf("found multiple hosts in same hostname (hostname: %s)", hostname)
+	}
+	host := hosts[0]
+
+	if opts.needUpdate(&host) {
+		return d.UpdateHost(ctx, host.ID, *opts)
+	}
+
+	return &host, nil
+}
+
+// RemoveClusterNode remove host from cluster.
+// volumes in cluster are not remapped, host object and initiator are kept.
+func (d *Device) RemoveClusterNode(ctx context.Context, clusterName, hostname string) error {
+	cluster, err := d.GetCluster(ctx, clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to get cluster: %w", err)
+	}
+
+	hosts, err := d.GetHosts(ctx, NewSearchQueryHostname(hostname))
+	if err != nil {
+		return fmt.Errorf("failed to get host: %w", err)
+	}
+	if len(hosts) != 1 {
+		return fmt.Errorf("found multiple hosts in same hostname (hostname: %s)", hostname)
+	}
+	host := hosts[0]
+
+	if host.ISADD2HOSTGROUP == false || host.PARENTID != strconv.Itoa(cluster.HostGroup.ID) {
+		return fmt.Errorf("host is not a node of cluster (hostname: %s, cluster: %s)", hostname, clusterName)
+	}
+
+	err = d.DisAssociateHost(ctx, cluster.HostGroup.ID, host.ID)
+	if err != nil {
+		return fmt.Errorf("failed to disassociate host from hostgroup: %w", err)
+	}
+
+	return nil
+}
+
+// AttachClusterVolume attach LUN to all hosts in cluster.
+func (d *Device) AttachClusterVolume(ctx context.Context, portgroupName, clusterName string, lunID int) error {
+	cluster, err := d.GetClusterForce(ctx, portgroupName, clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to get cluster: %w", err)
+	}
+
+	err = d.AssociateLun(ctx, cluster.LunGroup.ID, lunID)
+	if err != nil {
+		return fmt.Errorf("failed to associate lun to lungroup: %w", err)
+	}
+
+	return nil
+}
+
+// DetachClusterVolume detach LUN from all hosts in cluster.
+func (d *Device) DetachClusterVolume(ctx context.Context, clusterName string, lunID int) error {
+	cluster, err := d.GetCluster(ctx, clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to get cluster: %w", err)
+	}
+
+	err = d.DisAssociateLun(ctx, cluster.LunGroup.ID, lunID)
+	if err != nil {
+		return fmt.Errorf("failed to disassociate lun: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteCluster delete objects of cluster.
+// cluster must not have any hosts and volumes.
+func (d *Device) DeleteCluster(ctx context.Context, clusterName string) error {
+	cluster, err := d.GetCluster(ctx, clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to get cluster: %w", err)
+	}
+
+	if cluster.LunGroup.IsAssociated() {
+		return errors.New("cluster has volumes yet")
+	}
+	hosts, err := d.GetHostGroupAssociatedHosts(ctx, cluster.HostGroup.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get hosts in cluster: %w", err)
+	}
+	if len(hosts) != 0 {
+		return errors.New("cluster has hosts yet")
+	}
+
+	param := AssociateParam{
+		ID:   strconv.Itoa(cluster.MappingView.ID),
+		TYPE: strconv.Itoa(TypeMappingView),
+	}
+	portgroups, err := d.GetPortGroupsAssociate(ctx, cluster.MappingView.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get portgroup: %w", err)
+	}
+	for _, portgroup := range portgroups {
+		param.ASSOCIATEOBJTYPE = TypePortGroup
+		param.ASSOCIATEOBJID = strconv.Itoa(portgroup.ID)
+		if err := d.DisAssociateMappingView(ctx, param); err != nil {
+			return fmt.Errorf("failed to disassociate portgroup: %w", err)
+		}
+	}
+	param.ASSOCIATEOBJTYPE = TypeLUNGroup
+	param.ASSOCIATEOBJID = strconv.Itoa(cluster.LunGroup.ID)
+	if err := d.DisAssociateMappingView(ctx, param); err != nil {
+		return fmt.Errorf("failed to disassociate lungroup: %w", err)
+	}
+	param.ASSOCIATEOBJTYPE = TypeHostGroup
+	param.ASSOCIATEOBJID = strconv.Itoa(cluster.HostGroup.ID)
+	if err := d.DisAssociateMappingView(ctx, param); err != nil {
+		return fmt.Errorf("failed to disassociate hostgroup: %w", err)
+	}
+
+	if err := d.DeleteMappingView(ctx, cluster.MappingView.ID); err != nil {
+		return fmt.Errorf("failed to delete mapping view: %w", err)
+	}
+	if err := d.DeleteLunGroup(ctx, cluster.LunGroup.ID); err != nil {
+		return fmt.Errorf("failed to delete lungroup: %w", err)
+	}
+	if err := d.DeleteHostGroup(ctx, cluster.HostGroup.ID); err != nil {
+		return fmt.Errorf("failed to delete hostgroup: %w", err)
+	}
+
+	return nil
+}
+
+// AddClusterNode add host to cluster in both devices.
+func (c *Client) AddClusterNode(ctx context.Context, clusterName, hostname, iqn string, opts *AttachVolumeOption) error {
+	if err := c.requireBothDevices(ctx); err != nil {
+		return err
+	}
+
+	_, err := c.LocalDevice.AddClusterNode(ctx, c.PortGroupName, clusterName, hostname, iqn, opts)
+	if err != nil {
+		return fmt.Errorf("failed to add cluster node in Local Device: %w", err)
+	}
+	_, err = c.RemoteDevice.AddClusterNode(ctx, c.PortGroupName, clusterName, hostname, iqn, opts.remoteOption())
+	if err != nil {
+		return fmt.Errorf("failed to add cluster node in Remote Device: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveClusterNode remove host from cluster in both devices.
+// in degraded mode, remove in reachable device and remove in other device after recovered.
+func (c *Client) RemoveClusterNode(ctx context.Context, clusterName, hostname string) error {
+	return c.runBothDevices(ctx, fmt.Sprintf("remove cluster node (cluster: %s, host: %s)", clusterName, hostname),
+		func(ctx context.Context) error {
+			return c.LocalDevice.RemoveClusterNode(ctx, clusterName, hostname)
+		},
+		func(ctx context.Context) error {
+			return c.RemoteDevice.RemoveClusterNode(ctx, clusterName, hostname)
+		},
+	)
+}
+
+// AttachClusterVolume attach HyperMetroPair to all hosts in cluster.
+func (c *Client) AttachClusterVolume(ctx context.Context, hyperMetroPairID, clusterName string) error {
+	if err := c.requireBothDevices(ctx); err != nil {
+		return err
+	}
+
+	volume, err := c.GetHyperMetroPair(ctx, hyperMetroPairID)
+	if err != nil {
+		return fmt.Errorf("failed to get volume information: %w", err)
+	}
+
+	err = c.LocalDevice.AttachClusterVolume(ctx, c.PortGroupName, clusterName, volume.LOCALOBJID)
+	if err != nil {
+		return fmt.Errorf("failed to attach cluster volume in Local Device: %w", err)
+	}
+	err = c.RemoteDevice.AttachClusterVolume(ctx, c.PortGroupName, clusterName, volume.REMOTEOBJID)
+	if err != nil {
+		return fmt.Errorf("failed to attach cluster volume in Remote Device: %w", err)
+	}
+
+	return nil
+}
+
+// DetachClusterVolume detach HyperMetroPair from all hosts in cluster.
+// in degraded mode, detach in reachable device and detach in other device after recovered.
+func (c *Client) DetachClusterVolume(ctx context.Context, hyperMetroPairID, clusterName string) error {
+	if c.RemoteDevice == nil {
+		return errors.New("Remote IPs is required")
+	}
+
+	volume, err := c.GetHyperMetroPair(ctx, hyperMetroPairID)
+	if err != nil {
+		return fmt.Errorf("failed to get volume information: %w", err)
+	}
+
+	return c.runBothDevices(ctx, fmt.Sprintf("detach cluster volume (ID: %s, cluster: %s)", hyperMetroPairID, clusterName),
+		func(ctx context.Context) error {
+			return c.LocalDevice.DetachClusterVolume(ctx, clusterName, volume.LOCALOBJID)
+		},
+		func(ctx context.Context) error {
+			return c.RemoteDevice.DetachClusterVolume(ctx, clusterName, volume.REMOTEOBJID)
+		},
+	)
+}
+
+// DeleteCluster delete objects of cluster in both devices.
+func (c *Client) DeleteCluster(ctx context.Context, clusterName string) error {
+	if err := c.requireBothDevices(ctx); err != nil {
+		return err
+	}
+
+	err := c.LocalDevice.DeleteCluster(ctx, clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to delete cluster in Local Device: %w", err)
+	}
+	err = c.RemoteDevice.DeleteCluster(ctx, clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to delete cluster in Remote Device: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package dorado
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDevice_GetCluster(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	testFilter := func(t *testing.T, r *http.Request) {
+		t.Helper()
+		if got := r.URL.Query().Get("filter"); got != "NAME::cluster-pacemaker01" {
+			t.Errorf("Request filter: %v, want %v", got, "NAME::cluster-pacemaker01")
+		}
+	}
+
+	mux.HandleFunc("/hostgroup", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFilter(t, r)
+		fmt.Fprint(w, `{"data": [{"DESCRIPTION": "cluster-pacemaker01", "ID": "3", "ISADD2MAPPINGVIEW": "true", "NAME": "cluster-pacemaker01", "TYPE": 14}], "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/lungroup", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFilter(t, r)
+		fmt.Fprint(w, `{"data": [{"DESCRIPTION": "cluster-pacemaker01", "ID": "4", "ISADD2MAPPINGVIEW": "true", "NAME": "cluster-pacemaker01", "TYPE": 256}], "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/mappingview", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFilter(t, r)
+		fmt.Fprint(w, `{"data": [{"DESCRIPTION": "", "ENABLEINBANDCOMMAND": "true", "ID": "5", "INBANDLUNWWN": "", "NAME": "cluster-pacemaker01", "TYPE": 245}], "error": {"code": 0, "description": "0"}}`)
+	})
+
+	cluster, err := client.LocalDevice.GetCluster(context.Background(), "pacemaker01")
+	if err != nil {
+		t.Fatalf("GetCluster return err: %s", err)
+	}
+
+	want := &Cluster{
+		Name: "pacemaker01",
+		HostGroup: &HostGroup{
+			DESCRIPTION:       "cluster-pacemaker01",
+			ID:                3,
+			ISADD2MAPPINGVIEW: true,
+			NAME:              "cluster-pacemaker01",
+			TYPE:              TypeHostGroup,
+		},
+		LunGroup: &LunGroup{
+			DESCRIPTION:       "cluster-pacemaker01",
+			ID:                4,
+			ISADD2MAPPINGVIEW: true,
+			NAME:              "cluster-pacemaker01",
+			TYPE:              TypeLUNGroup,
+		},
+		MappingView: &MappingView{
+			ENABLEINBANDCOMMAND: true,
+			ID:                  5,
+			NAME:                "cluster-pacemaker01",
+			TYPE:                TypeMappingView,
+		},
+	}
+
+	if !reflect.DeepEqual(cluster, want) {
+		t.Errorf("GetCluster return %+v, want %+v", cluster, want)
+	}
+}
+
+func TestDevice_GetCluster_OtherClusterName(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	// filter of REST API match partially, so object of other cluster is returned
+	mux.HandleFunc("/hostgroup", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": [{"DESCRIPTION": "cluster-pacemaker010", "ID": "3", "ISADD2MAPPINGVIEW": "true", "NAME": "cluster-pacemaker010", "TYPE": 14}], "error": {"code": 0, "description": "0"}}`)
+	})
+
+	_, err := client.LocalDevice.GetCluster(context.Background(), "pacemaker01")
+	if !errors.Is(err, ErrHostGroupNotFound) {
+		t.Errorf("GetCluster return err: %v, want %v", err, ErrHostGroupNotFound)
+	}
+}
@@ -0,0 +1,214 @@
+package dorado
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// FindingType is type of inconsistency
+type FindingType int
+
+// FindingTypes
+const (
+	// FindingSizeMismatch is capacity of LUNs are different between devices.
+	FindingSizeMismatch FindingType = iota + 1
+	// FindingAccessStateConflict is host access state of pair is conflicted.
+	FindingAccessStateConflict
+	// FindingMissingMemberLUN is LUN of pair is not found in a device.
+	FindingMissingMemberLUN
+	// FindingPairNotInDomain is domain of pair is not found.
+	FindingPairNotInDomain
+)
+
+// String return name of finding type
+func (t FindingType) String() string {
+	switch t {
+	case FindingSizeMismatch:
+		return "size mismatch"
+	case FindingAccessStateConflict:
+		return "access state conflict"
+	case FindingMissingMemberLUN:
+		return "missing member LUN"
+	case FindingPairNotInDomain:
+		return "pair not in domain"
+	default:
+		return fmt.Sprintf("unknown (%d)", int(t))
+	}
+}
+
+// RemediationAction is proposed action to resolve finding
+type RemediationAction int
+
+// RemediationActions
+const (
+	// ActionExtendSmallerLUN suspend pair, expand smaller LUN to larger capacity and re-sync (see ExtendVolume).
+	ActionExtendSmallerLUN RemediationAction = iota + 1
+	// ActionSyncFromPrimary suspend pair and re-sync from primary to fix host access state.
+	ActionSyncFromPrimary
+	// ActionForceStartPrimary force start primary because no side accept host access.
+	ActionForceStartPrimary
+	// ActionRecreatePair delete pair and create pair again with LUNs in both devices.
+	ActionRecreatePair
+)
+
+// String return description of action
+func (a RemediationAction) String() string {
+	switch a {
+	case ActionExtendSmallerLUN:
+		return "extend smaller LUN"
+	case ActionSyncFromPrimary:
+		return "suspend and sync from primary"
+	case ActionForceStartPrimary:
+		return "force start primary"
+	case ActionRecreatePair:
+		return "recreate pair"
+	default:
+		return fmt.Sprintf("unknown (%d)", int(a))
+	}
+}
+
+// Finding is inconsistency of HyperMetroPair
+type Finding struct {
+	Type             FindingType
+	HyperMetroPairID string
+	Message          string
+	Action           RemediationAction
+}
+
+// CheckConsistency cross-check all HyperMetroPairs against LUNs and HyperMetroDomains in both devices.
+func (c *Client) CheckConsistency(ctx context.Context) ([]Finding, error) {
+	if err := c.requireBothDevices(ctx); err != nil {
+		return nil, err
+	}
+
+	hmps, err := getAllHyperMetroPairs(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HyperMetroPairs: %w", err)
+	}
+	if len(hmps) == 0 {
+		return nil, nil
+	}
+
+	localLUNs, err := getLUNsByID(ctx, c.LocalDevice)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LUNs in Local Device: %w", err)
+	}
+	remoteLUNs, err := getLUNsByID(ctx, c.RemoteDevice)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LUNs in Remote Device: %w", err)
+	}
+
+	domains := map[string]bool{}
+	hmds, err := c.GetHyperMetroDomains(ctx, nil)
+	if err != nil && err != ErrHyperMetroDomainNotFound {
+		return nil, fmt.Errorf("failed to get HyperMetroDomains: %w", err)
+	}
+	for _, hmd := range hmds {
+		domains[hmd.ID] = true
+	}
+
+	var findings []Finding
+	for _, hmp := range hmps {
+		findings = append(findings, checkHyperMetroPair(hmp, localLUNs, remoteLUNs, domains)...)
+	}
+
+	return findings, nil
+}
+
+// pageSize is number of objects per request in getLUNsByID and getAllHyperMetroPairs
+const pageSize = 4096
+
+// getLUNsByID get all LUNs in device. LUNs are requested per page until response is empty.
+func getLUNsByID(ctx context.Context, d *Device) (map[int]LUN, error) {
+	lunsByID := map[int]LUN{}
+	for start := 0; ; {
+		luns, err := d.GetLUNs(ctx, &SearchQuery{
+			Range: fmt.Sprintf("[%d-%d]", start, start+pageSize),
+		})
+		if err == ErrLunNotFound {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, lun := range luns {
+			lunsByID[lun.ID] = lun
+		}
+		start += len(luns)
+	}
+
+	return lunsByID, nil
+}
+
+// getAllHyperMetroPairs get all HyperMetroPairs. HyperMetroPairs are requested per page until response is empty.
+func getAllHyperMetroPairs(ctx context.Context, c *Client) ([]HyperMetroPair, error) {
+	var hmps []HyperMetroPair
+	seen := map[string]bool{}
+	for start := 0; ; {
+		page, err := c.GetHyperMetroPairs(ctx, &SearchQuery{
+			Range: fmt.Sprintf("[%d-%d]", start, start+pageSize),
+		})
+		if err == ErrHyperMetroPairNotFound {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, hmp := range page {
+			if !seen[hmp.ID] {
+				seen[hmp.ID] = true
+				hmps = append(hmps, hmp)
+			}
+		}
+		start += len(page)
+	}
+
+	return hmps, nil
+}
+
+func checkHyperMetroPair(hmp HyperMetroPair, localLUNs, remoteLUNs map[int]LUN, domains map[string]bool) []Finding {
+	var findings []Finding
+	add := func(t FindingType, action RemediationAction, format string, a ...interface{}) {
+		findings = append(findings, Finding{
+			Type:             t,
+			HyperMetroPairID: hmp.ID,
+			Message:          fmt.Sprintf(format, a...),
+			Action:           action,
+		})
+	}
+
+	if !domains[hmp.DOMAINID] {
+		add(FindingPairNotInDomain, ActionRecreatePair, "HyperMetroDomain (ID: %s) is not found", hmp.DOMAINID)
+	}
+
+	localLUN, localOK := localLUNs[hmp.LOCALOBJID]
+	remoteLUN, remoteOK := remoteLUNs[hmp.REMOTEOBJID]
+	if !localOK {
+		add(FindingMissingMemberLUN, ActionRecreatePair, "LUN (ID: %d) is not found in Local Device", hmp.LOCALOBJID)
+	}
+	if !remoteOK {
+		add(FindingMissingMemberLUN, ActionRecreatePair, "LUN (ID: %d) is not found in Remote Device", hmp.REMOTEOBJID)
+	}
+	if localOK && remoteOK && localLUN.CAPACITY != remoteLUN.CAPACITY {
+		add(FindingSizeMismatch, ActionExtendSmallerLUN, "capacity is different (local: %d, remote: %d)", localLUN.CAPACITY, remoteLUN.CAPACITY)
+	}
+
+	localRW := hmp.LOCALHOSTACCESSSTATE == strconv.Itoa(HostAccessStateReadWrite)
+	remoteRW := hmp.REMOTEHOSTACCESSSTATE == strconv.Itoa(HostAccessStateReadWrite)
+	switch state := hmp.State(); state {
+	case HyperMetroPairStateNormal, HyperMetroPairStateSynchronizing:
+		// both sides accept write while pair is synced
+	default:
+		if localRW && remoteRW {
+			add(FindingAccessStateConflict, ActionSyncFromPrimary, "both devices accept write while pair is %s (split-brain)", state)
+		}
+		if !localRW && !remoteRW && state == HyperMetroPairStateForcedStart {
+			add(FindingAccessStateConflict, ActionForceStartPrimary, "no device accept write while pair is %s", state)
+		}
+	}
+
+	return findings
+}
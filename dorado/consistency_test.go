@@ -0,0 +1,115 @@
+package dorado
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCheckHyperMetroPair(t *testing.T) {
+	localLUNs := map[int]LUN{
+		216: {ID: 216, CAPACITY: 20971520},
+		217: {ID: 217, CAPACITY: 20971520},
+	}
+	remoteLUNs := map[int]LUN{
+		514: {ID: 514, CAPACITY: 41943040},
+		515: {ID: 515, CAPACITY: 20971520},
+	}
+	domains := map[string]bool{"e4c2d1eaf02c0100": true}
+
+	tests := []struct {
+		input HyperMetroPair
+		want  []FindingType
+	}{
+		{
+			input: HyperMetroPair{ID: "1", DOMAINID: "e4c2d1eaf02c0100", LOCALOBJID: 217, REMOTEOBJID: 515, RUNNINGSTATUS: "1", LOCALHOSTACCESSSTATE: "3", REMOTEHOSTACCESSSTATE: "3"},
+			want:  nil,
+		},
+		{
+			input: HyperMetroPair{ID: "2", DOMAINID: "e4c2d1eaf02c0100", LOCALOBJID: 216, REMOTEOBJID: 514, RUNNINGSTATUS: "41", LOCALHOSTACCESSSTATE: "3", REMOTEHOSTACCESSSTATE: "1"},
+			want:  []FindingType{FindingSizeMismatch},
+		},
+		{
+			input: HyperMetroPair{ID: "3", DOMAINID: "e4c2d1eaf02c0100", LOCALOBJID: 217, REMOTEOBJID: 515, RUNNINGSTATUS: "41", LOCALHOSTACCESSSTATE: "3", REMOTEHOSTACCESSSTATE: "3"},
+			want:  []FindingType{FindingAccessStateConflict},
+		},
+		{
+			input: HyperMetroPair{ID: "4", DOMAINID: "unknown", LOCALOBJID: 218, REMOTEOBJID: 515, RUNNINGSTATUS: "41", LOCALHOSTACCESSSTATE: "3", REMOTEHOSTACCESSSTATE: "1"},
+			want:  []FindingType{FindingPairNotInDomain, FindingMissingMemberLUN},
+		},
+	}
+
+	for _, test := range tests {
+		var got []FindingType
+		for _, f := range checkHyperMetroPair(test.input, localLUNs, remoteLUNs, domains) {
+			got = append(got, f.Type)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("checkHyperMetroPair(ID: %s) return %v, want %v", test.input.ID, got, test.want)
+		}
+	}
+}
+
+func TestGetLUNsByID_Paging(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var ranges []string
+	mux.HandleFunc("/lun", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		ranges = append(ranges, r.URL.Query().Get("range"))
+		switch len(ranges) {
+		case 1:
+			fmt.Fprint(w, `{"data": [{"ID": "1", "TYPE": 11}, {"ID": "2", "TYPE": 11}], "error": {"code": 0, "description": "0"}}`)
+		case 2:
+			fmt.Fprint(w, `{"data": [{"ID": "5000", "TYPE": 11}], "error": {"code": 0, "description": "0"}}`)
+		default:
+			fmt.Fprint(w, `{"data": [], "error": {"code": 0, "description": "0"}}`)
+		}
+	})
+
+	luns, err := getLUNsByID(context.Background(), client.LocalDevice)
+	if err != nil {
+		t.Fatalf("getLUNsByID return err: %s", err)
+	}
+	if len(luns) != 3 {
+		t.Errorf("getLUNsByID return %d LUNs, want 3", len(luns))
+	}
+	want := []string{"[0-4096]", "[2-4098]", "[3-4099]"}
+	if !reflect.DeepEqual(ranges, want) {
+		t.Errorf("getLUNsByID request range %v, want %v", ranges, want)
+	}
+}
+
+func TestGetAllHyperMetroPairs_Paging(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var ranges []string
+	mux.HandleFunc("/HyperMetroPair", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		ranges = append(ranges, r.URL.Query().Get("range"))
+		switch len(ranges) {
+		case 1:
+			fmt.Fprint(w, `{"data": [{"ID": "a", "LOCALOBJID": "1", "REMOTEOBJID": "1"}, {"ID": "b", "LOCALOBJID": "2", "REMOTEOBJID": "2"}], "error": {"code": 0, "description": "0"}}`)
+		case 2:
+			fmt.Fprint(w, `{"data": [{"ID": "c", "LOCALOBJID": "5000", "REMOTEOBJID": "5000"}], "error": {"code": 0, "description": "0"}}`)
+		default:
+			fmt.Fprint(w, `{"data": [], "error": {"code": 0, "description": "0"}}`)
+		}
+	})
+
+	hmps, err := getAllHyperMetroPairs(context.Background(), client)
+	if err != nil {
+		t.Fatalf("getAllHyperMetroPairs return err: %s", err)
+	}
+	if len(hmps) != 3 {
+		t.Errorf("getAllHyperMetroPairs return %d HyperMetroPairs, want 3", len(hmps))
+	}
+	want := []string{"[0-4096]", "[2-4098]", "[3-4099]"}
+	if !reflect.DeepEqual(ranges, want) {
+		t.Errorf("getAllHyperMetroPairs request range %v, want %v", ranges, want)
+	}
+}
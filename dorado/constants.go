@@ -27,6 +27,19 @@ const (
 	TypeEthernetPort     = 213
 	TypeHyperMetroPair   = 15361
 	TypeHyperMetroDomain = 15362
+
+	TypeHyperMetroConsistencyGroup = 15364
+	TypeQuorumServer               = 15365
+	TypeQuorumServerLink           = 15366
+
+	TypeReplicationPair             = 263
+	TypeReplicationConsistencyGroup = 57702
+
+	TypeProtectionGroup          = 57615
+	TypeSnapshotConsistencyGroup = 57646
+
+	TypeQoSPolicy    = 230
+	TypeLUNMigration = 253
 )
 
 // For HyperMetroPair RUNNINGSTATUS
@@ -39,6 +52,87 @@ const (
 	StatusToBeSynchronized = 100
 )
 
+// For HyperMetroPair RECOVERYPOLICY
+const (
+	RecoveryPolicyAutomatic = 1
+	RecoveryPolicyManual    = 2
+)
+
+// For HyperMetro consistency group PRIORITYSTATIONTYPE
+const (
+	PriorityStationPreferred    = 0
+	PriorityStationNonPreferred = 1
+)
+
+// For HyperMetroPair SYNCDIRECTION
+const (
+	SyncDirectionLocalToRemote = 1
+	SyncDirectionRemoteToLocal = 2
+)
+
+// For a some SPEED
+const (
+	SpeedLow     = 1
+	SpeedMedium  = 2
+	SpeedHigh    = 3
+	SpeedHighest = 4
+)
+
+// For LUN Clone SPLITACTION
+const (
+	SplitActionStart = 1
+	SplitActionStop  = 2
+	SplitActionPause = 3
+)
+
+// For HyperMetroPair LOCALHOSTACCESSSTATE and REMOTEHOSTACCESSSTATE
+const (
+	HostAccessStateNoAccess  = 1
+	HostAccessStateReadOnly  = 2
+	HostAccessStateReadWrite = 3
+)
+
+// For HyperMetroDomain CPTYPE
+const (
+	CPTypeStaticPriority = 1
+	CPTypeQuorumServer   = 2
+)
+
+// For replication REPLICATIONMODEL
+const (
+	ReplicationModelSync  = 1
+	ReplicationModelAsync = 2
+)
+
+// For replication SYNCHRONIZETYPE (use only REPLICATIONMODEL is async)
+const (
+	SynchronizeTypeManual          = 1
+	SynchronizeTypeTimedAfterStart = 2
+	SynchronizeTypeTimedAfterEnd   = 3
+)
+
+// For replication RUNNINGSTATUS
+const (
+	StatusReplicationNormal        = 1
+	StatusReplicationSynchronizing = 23
+	StatusReplicationSplit         = 26
+	StatusReplicationToBeRecovered = 33
+	StatusReplicationInterrupted   = 34
+	StatusReplicationInvalid       = 35
+)
+
+// For replication SECRESACCESS
+const (
+	SecondaryAccessReadOnly  = 2
+	SecondaryAccessReadWrite = 3
+)
+
+// For link RUNNINGSTATUS
+const (
+	StatusLinkUp   = 10
+	StatusLinkDown = 11
+)
+
 // For HEALTHSTATUS status
 const (
 	StatusHealth = 1
@@ -52,6 +146,91 @@ const (
 	StatusSnapshotInactive = 45
 )
 
+// For Snapshot RUNNINGSTATUS while rollback
+const (
+	StatusSnapshotRollingBack = 44
+)
+
+// For LUN Copy RUNNINGSTATUS
+const (
+	StatusLunCopyQueuing = 37
+	StatusLunCopyStopped = 38
+	StatusLunCopyCopying = 39
+	StatusLunCopyPaused  = 41
+)
+
+// For LUN Migration RUNNINGSTATUS
+const (
+	StatusLunMigrationFault     = 74
+	StatusLunMigrationMigrating = 75
+	StatusLunMigrationComplete  = 76
+)
+
+// For LUN Copy LUNCOPYTYPE
+const (
+	LUNCopyTypeFull        = 1
+	LUNCopyTypeIncremental = 2
+)
+
+// For Host OPERATIONSYSTEM
+const (
+	OSLinux             = 0
+	OSWindows           = 1
+	OSSolaris           = 2
+	OSHPUX              = 3
+	OSAIX               = 4
+	OSXenServer         = 5
+	OSMacOS             = 6
+	OSVMwareESX         = 7
+	OSWindowsServer2012 = 9
+)
+
+// For Initiator MULTIPATHTYPE
+const (
+	MultipathTypeDefault    = 0
+	MultipathTypeThirdParty = 1
+)
+
+// For Initiator FAILOVERMODE (use only MULTIPATHTYPE is third-party)
+const (
+	FailoverModeOldALUA     = 0
+	FailoverModeCommonALUA  = 1
+	FailoverModeALUANotUsed = 2
+	FailoverModeSpecialALUA = 3
+)
+
+// For Initiator SPECIALMODETYPE (use only FAILOVERMODE is special ALUA)
+const (
+	SpecialModeType0 = 0
+	SpecialModeType1 = 1
+	SpecialModeType2 = 2
+	SpecialModeType3 = 3
+)
+
+// For Initiator PATHTYPE
+const (
+	PathTypeOptimized    = 0
+	PathTypeNonOptimized = 1
+)
+
+// Range of host LUN ID
+const (
+	MinHostLUNID = 0
+	MaxHostLUNID = 4095
+)
+
+// For Initiator NORMALVERMODE (CHAP authentication mode)
+const (
+	CHAPModeOneWay = 0
+	CHAPModeMutual = 1
+)
+
+// Length of CHAP secret that Dorado accept
+const (
+	MinCHAPSecretLength = 12
+	MaxCHAPSecretLength = 16
+)
+
 // Dorado return Error Codes
 const (
 	ErrorCodeUnAuthorized  = -401
@@ -75,6 +254,29 @@ var (
 	ErrStoragePoolNotFound      = errors.New("storage pool is not found")
 	ErrTargetPortNotFound       = errors.New("target port is not found")
 
+	ErrHyperMetroConsistencyGroupNotFound = errors.New("HyperMetro consistency group is not found")
+	ErrHyperMetroPairUnsafeTransition     = errors.New("HyperMetroPair state is not allowed to operate")
+	ErrHyperMetroPairUnhealthy            = errors.New("HyperMetroPair is not healthy")
+	ErrHyperMetroPairNotSyncing           = errors.New("HyperMetroPair is not syncing")
+	ErrQuorumServerNotFound               = errors.New("quorum server is not found")
+	ErrQuorumServerLinkNotFound           = errors.New("quorum server link is not found")
+
+	ErrDegraded                            = errors.New("client is degraded mode")
+	ErrRemoteArrayNotFound                 = errors.New("remote device is not found")
+	ErrReplicationPairNotFound             = errors.New("replication pair is not found")
+	ErrReplicationConsistencyGroupNotFound = errors.New("replication consistency group is not found")
+
+	ErrSnapshotConsistencyGroupNotFound = errors.New("snapshot consistency group is not found")
+	ErrSnapshotMapped                   = errors.New("snapshot is mapped to host")
+
+	ErrLunHasClones = errors.New("LUN has dependent clone LUNs")
+
+	ErrQoSPolicyNotFound     = errors.New("QoS policy is not found")
+	ErrLunMigrationNotFound  = errors.New("LUN migration is not found")
+	ErrLunMigrationNotFinish = errors.New("LUN migration is not completed")
+
+	ErrHostLUNIDMismatch = errors.New("host LUN ID is not requested value")
+
 	ErrUnAuthorized = errors.New("failed to authorized token")
 	ErrTimeoutWait  = errors.New("timeout to wait")
 
@@ -0,0 +1,392 @@
+package dorado
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DeviceSide is side of device in Client
+type DeviceSide int
+
+// DeviceSides
+const (
+	SideNone DeviceSide = iota
+	SideLocal
+	SideRemote
+)
+
+// String return name of side
+func (s DeviceSide) String() string {
+	switch s {
+	case SideNone:
+		return "none"
+	case SideLocal:
+		return "Local Device"
+	case SideRemote:
+		return "Remote Device"
+	default:
+		return fmt.Sprintf("unknown (%d)", int(s))
+	}
+}
+
+func (s DeviceSide) other() DeviceSide {
+	switch s {
+	case SideLocal:
+		return SideRemote
+	case SideRemote:
+		return SideLocal
+	default:
+		return SideNone
+	}
+}
+
+// degradedState is state of degraded mode.
+// Client has degradedState only if created by NewClientAllowDegraded.
+type degradedState struct {
+	mu          sync.Mutex
+	unreachable DeviceSide
+	pending     []pendingOperation
+	recovering  bool
+}
+
+// pendingOperation is operation to unreachable device, it is run after device is recovered.
+type pendingOperation struct {
+	description string
+	run         func(ctx context.Context) error
+}
+
+// NewClientAllowDegraded create go-dorado-sdk client that allow one device is unreachable.
+// if one device is unreachable, Client is degraded mode:
+// - read operations are served from reachable device
+// - detach operations are run in reachable device, and run in other device after recovered
+// - other operations that change both devices return ErrDegraded
+func NewClientAllowDegraded(localIPs, remoteIPs []string, username, password, portgroupName string, logger *log.Logger) (*Client, error) {
+	client, err := NewClientDefaultToken(localIPs, remoteIPs, username, password, portgroupName, logger)
+	if err != nil {
+		return nil, err
+	}
+	if client.RemoteDevice == nil {
+		return nil, errors.New("Remote IPs is required")
+	}
+	client.degraded = &degradedState{}
+
+	err = client.SetTokenAllowDegraded()
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// SetTokenAllowDegraded call REST API and set iBaseToken to client.
+// Client become degraded mode if one device is unreachable.
+func (c *Client) SetTokenAllowDegraded() error {
+	if c.degraded == nil {
+		c.degraded = &degradedState{}
+	}
+
+	lerr := c.LocalDevice.setToken()
+	rerr := c.RemoteDevice.setToken()
+	switch {
+	case lerr != nil && rerr != nil:
+		return fmt.Errorf("failed to set token in both devices: local: %s, remote: %w", lerr, rerr)
+	case lerr != nil:
+		c.markDegraded(SideLocal, lerr)
+	case rerr != nil:
+		c.markDegraded(SideRemote, rerr)
+	}
+
+	return nil
+}
+
+func (c *Client) markDegraded(side DeviceSide, cause error) {
+	c.degraded.mu.Lock()
+	defer c.degraded.mu.Unlock()
+
+	if c.degraded.unreachable == SideNone {
+		c.Logger.Printf("WARNING: %s is unreachable, client is degraded mode: %s", side, cause)
+	}
+	c.degraded.unreachable = side
+}
+
+// UnreachableSide return side of unreachable device. return SideNone if not degraded.
+func (c *Client) UnreachableSide() DeviceSide {
+	if c.degraded == nil {
+		return SideNone
+	}
+
+	c.degraded.mu.Lock()
+	defer c.degraded.mu.Unlock()
+	return c.degraded.unreachable
+}
+
+// IsDegraded return true if one device is unreachable.
+func (c *Client) IsDegraded() bool {
+	return c.UnreachableSide() != SideNone
+}
+
+// PendingOperations return descriptions of operations that wait to recover device.
+func (c *Client) PendingOperations() []string {
+	if c.degraded == nil {
+		return nil
+	}
+
+	c.degraded.mu.Lock()
+	defer c.degraded.mu.Unlock()
+	var descriptions []string
+	for _, op := range c.degraded.pending {
+		descriptions = append(descriptions, op.description)
+	}
+	return descriptions
+}
+
+func (c *Client) device(side DeviceSide) *Device {
+	if side == SideRemote {
+		return c.RemoteDevice
+	}
+	return c.LocalDevice
+}
+
+// Recover try to connect unreachable device and run pending operations.
+// return true if Client is not degraded mode.
+func (c *Client) Recover(ctx context.Context) (bool, error) {
+	if c.degraded == nil {
+		return true, nil
+	}
+
+	c.degraded.mu.Lock()
+	side := c.degraded.unreachable
+	if side == SideNone {
+		c.degraded.mu.Unlock()
+		return true, nil
+	}
+	if c.degraded.recovering {
+		// other goroutine is running pending operations
+		c.degraded.mu.Unlock()
+		return false, nil
+	}
+	c.degraded.recovering = true
+	c.degraded.mu.Unlock()
+	defer func() {
+		c.degraded.mu.Lock()
+		c.degraded.recovering = false
+		c.degraded.mu.Unlock()
+	}()
+
+	if err := c.device(side).setToken(); err != nil {
+		return false, fmt.Errorf("failed to set token in %s: %w", side, err)
+	}
+
+	for {
+		// operations may be added while running, so copy and run until pending is empty.
+		c.degraded.mu.Lock()
+		pending := append([]pendingOperation(nil), c.degraded.pending...)
+		if len(pending) == 0 {
+			c.degraded.unreachable = SideNone
+			c.degraded.mu.Unlock()
+			break
+		}
+		c.degraded.mu.Unlock()
+
+		for _, op := range pending {
+			err := op.run(ctx)
+			switch {
+			case err == nil:
+				c.Logger.Printf("reconciled %s in %s", op.description, side)
+			case isAlreadyReconciled(err):
+				// object is removed after operation is queued, nothing to do.
+				c.Logger.Printf("skip %s in %s: %s", op.description, side, err)
+			default:
+				return false, fmt.Errorf("failed to reconcile %s in %s: %w", op.description, side, err)
+			}
+
+			c.degraded.mu.Lock()
+			c.degraded.pending = c.degraded.pending[1:]
+			c.degraded.mu.Unlock()
+		}
+	}
+
+	c.Logger.Printf("%s is recovered, client is normal mode", side)
+	return true, nil
+}
+
+// isAlreadyReconciled return true if err means target object of pending operation is not exist.
+func isAlreadyReconciled(err error) bool {
+	for _, notFound := range []error{
+		ErrLunNotFound,
+		ErrLunGroupNotFound,
+		ErrHostNotFound,
+		ErrHostGroupNotFound,
+		ErrMappingViewNotFound,
+	} {
+		if errors.Is(err, notFound) {
+			return true
+		}
+	}
+	return false
+}
+
+// DiscardPendingOperation remove first pending operation that can't be reconciled.
+// return description of discarded operation.
+func (c *Client) DiscardPendingOperation() (string, error) {
+	if c.degraded == nil {
+		return "", errors.New("pending operation is not found")
+	}
+
+	c.degraded.mu.Lock()
+	defer c.degraded.mu.Unlock()
+	if c.degraded.recovering {
+		return "", errors.New("can't discard pending operation while recovering")
+	}
+	if len(c.degraded.pending) == 0 {
+		return "", errors.New("pending operation is not found")
+	}
+
+	op := c.degraded.pending[0]
+	c.degraded.pending = c.degraded.pending[1:]
+	c.Logger.Printf("WARNING: discard %s, it is not run in %s", op.description, c.degraded.unreachable)
+	return op.description, nil
+}
+
+// recoveryLogInterval is interval to log same error in WatchRecovery.
+const recoveryLogInterval = 10 * time.Minute
+
+// WatchRecovery call Recover per interval until recovered or ctx is done.
+// same error is logged once per recoveryLogInterval.
+func (c *Client) WatchRecovery(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastErr string
+	var lastLogged time.Time
+	for {
+		recovered, err := c.Recover(ctx)
+		if err != nil && (err.Error() != lastErr || time.Since(lastLogged) >= recoveryLogInterval) {
+			c.Logger.Printf("failed to recover: %s", err)
+			lastErr = err.Error()
+			lastLogged = time.Now()
+		}
+		if recovered {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// requireBothDevices return error if operation can't run in degraded mode.
+// try to recover before return ErrDegraded.
+func (c *Client) requireBothDevices(ctx context.Context) error {
+	if c.RemoteDevice == nil {
+		return errors.New("Remote IPs is required")
+	}
+	if !c.IsDegraded() {
+		return nil
+	}
+
+	recovered, err := c.Recover(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to recover (%s): %w", err, ErrDegraded)
+	}
+	if !recovered {
+		return fmt.Errorf("%s is unreachable: %w", c.UnreachableSide(), ErrDegraded)
+	}
+
+	return nil
+}
+
+// runBothDevices run operation that safe to run in one device.
+// if a device is unreachable, run in reachable device and run in other device after recovered.
+func (c *Client) runBothDevices(ctx context.Context, description string, local, remote func(ctx context.Context) error) error {
+	if c.RemoteDevice == nil {
+		return errors.New("Remote IPs is required")
+	}
+	run := map[DeviceSide]func(ctx context.Context) error{
+		SideLocal:  local,
+		SideRemote: remote,
+	}
+
+	if unreachable := c.UnreachableSide(); unreachable != SideNone {
+		if err := run[unreachable.other()](ctx); err != nil {
+			return fmt.Errorf("failed to %s in %s: %w", description, unreachable.other(), err)
+		}
+		c.addPending(unreachable, description, run[unreachable])
+		return nil
+	}
+
+	unreachableErrs := map[DeviceSide]error{}
+	for _, side := range []DeviceSide{SideLocal, SideRemote} {
+		err := run[side](ctx)
+		if err == nil {
+			continue
+		}
+		if c.degraded == nil || c.isReachable(ctx, side) {
+			return fmt.Errorf("failed to %s in %s: %w", description, side, err)
+		}
+		unreachableErrs[side] = err
+	}
+	if len(unreachableErrs) == 2 {
+		return fmt.Errorf("failed to %s in both devices: local: %s, remote: %w", description, unreachableErrs[SideLocal], unreachableErrs[SideRemote])
+	}
+
+	for side, err := range unreachableErrs {
+		c.markDegraded(side, err)
+		c.addPending(side, description, run[side])
+	}
+
+	return nil
+}
+
+func (c *Client) addPending(side DeviceSide, description string, run func(ctx context.Context) error) {
+	c.degraded.mu.Lock()
+	defer c.degraded.mu.Unlock()
+
+	c.Logger.Printf("WARNING: %s is unreachable, %s will be run after recovered", side, description)
+	c.degraded.pending = append(c.degraded.pending, pendingOperation{
+		description: description,
+		run:         run,
+	})
+}
+
+func (c *Client) isReachable(ctx context.Context, side DeviceSide) bool {
+	_, err := c.device(side).GetSystem(ctx)
+	return err == nil
+}
+
+// hyperMetroDevice return device that serve HyperMetroPair.
+// return RemoteDevice if LocalDevice is unreachable.
+func (c *Client) hyperMetroDevice() (*Device, bool) {
+	if c.UnreachableSide() == SideLocal {
+		return c.RemoteDevice, true
+	}
+	return c.LocalDevice, false
+}
+
+// toLocalView convert HyperMetroPair in RemoteDevice to view of LocalDevice.
+func (hmp *HyperMetroPair) toLocalView() {
+	hmp.LOCALOBJID, hmp.REMOTEOBJID = hmp.REMOTEOBJID, hmp.LOCALOBJID
+	hmp.LOCALOBJNAME, hmp.REMOTEOBJNAME = hmp.REMOTEOBJNAME, hmp.LOCALOBJNAME
+	hmp.LOCALDATASTATE, hmp.REMOTEDATASTATE = hmp.REMOTEDATASTATE, hmp.LOCALDATASTATE
+	hmp.LOCALHOSTACCESSSTATE, hmp.REMOTEHOSTACCESSSTATE = hmp.REMOTEHOSTACCESSSTATE, hmp.LOCALHOSTACCESSSTATE
+	hmp.ISPRIMARY = flipBoolString(hmp.ISPRIMARY)
+}
+
+func flipBoolString(s string) string {
+	switch s {
+	case "true":
+		return "false"
+	case "false":
+		return "true"
+	default:
+		return s
+	}
+}
@@ -0,0 +1,232 @@
+package dorado
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+func TestClient_GetHyperMetroPair_Degraded(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.degraded = &degradedState{unreachable: SideLocal}
+
+	mux.HandleFunc("/HyperMetroPair/e4c2d1eaf02c0001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		// response from Remote Device
+		fmt.Fprint(w, `{"data": {"ID": "e4c2d1eaf02c0001", "ISPRIMARY": "false", "LOCALOBJID": "514", "REMOTEOBJID": "216", "RUNNINGSTATUS": "41", "TYPE": 15361}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	hmp, err := client.GetHyperMetroPair(context.Background(), "e4c2d1eaf02c0001")
+	if err != nil {
+		t.Fatalf("GetHyperMetroPair return err: %s", err)
+	}
+	if hmp.LOCALOBJID != 216 || hmp.REMOTEOBJID != 514 || hmp.ISPRIMARY != "true" {
+		t.Errorf("GetHyperMetroPair return %+v, want view of Local Device", hmp)
+	}
+}
+
+func TestClient_CreateVolumeRaw_Degraded(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+	client.degraded = &degradedState{unreachable: SideRemote}
+
+	_, err := client.CreateVolumeRaw(context.Background(), uuid.UUID{}, 10, "pool", "domain", nil)
+	if !errors.Is(err, ErrDegraded) {
+		t.Errorf("CreateVolumeRaw return err: %v, want %v", err, ErrDegraded)
+	}
+}
+
+func TestClient_runBothDevices_Degraded(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+	client.degraded = &degradedState{unreachable: SideRemote}
+
+	var called []DeviceSide
+	err := client.runBothDevices(context.Background(), "test",
+		func(ctx context.Context) error {
+			called = append(called, SideLocal)
+			return nil
+		},
+		func(ctx context.Context) error {
+			called = append(called, SideRemote)
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("runBothDevices return err: %s", err)
+	}
+	if len(called) != 1 || called[0] != SideLocal {
+		t.Errorf("runBothDevices called %v, want only %s", called, SideLocal)
+	}
+	if ops := client.PendingOperations(); len(ops) != 1 || ops[0] != "test" {
+		t.Errorf("PendingOperations return %v, want [test]", ops)
+	}
+}
+
+func TestClient_Recover(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.degraded = &degradedState{unreachable: SideRemote}
+
+	mux.HandleFunc("/sessions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": {"iBaseToken": "dummy_token", "deviceid": "xx"}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	// pending operation can use Client while recovering
+	client.addPending(SideRemote, "test", func(ctx context.Context) error {
+		if ops := client.PendingOperations(); len(ops) != 1 {
+			t.Errorf("PendingOperations return %v while recovering, want [test]", ops)
+		}
+		return nil
+	})
+
+	recovered, err := client.Recover(context.Background())
+	if err != nil {
+		t.Fatalf("Recover return err: %s", err)
+	}
+	if !recovered || client.IsDegraded() {
+		t.Errorf("Recover must recover client")
+	}
+	if ops := client.PendingOperations(); len(ops) != 0 {
+		t.Errorf("PendingOperations return %v, want empty", ops)
+	}
+}
+
+func TestClient_Recover_Unreachable(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+	client.degraded = &degradedState{unreachable: SideRemote}
+
+	recovered, err := client.Recover(context.Background())
+	if err == nil {
+		t.Errorf("Recover must return err if failed to set token")
+	}
+	if recovered {
+		t.Errorf("Recover must not recover client")
+	}
+}
+
+func TestClient_Recover_AlreadyReconciled(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.degraded = &degradedState{unreachable: SideRemote}
+
+	mux.HandleFunc("/sessions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": {"iBaseToken": "dummy_token", "deviceid": "xx"}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	var called []string
+	client.addPending(SideRemote, "detach volume", func(ctx context.Context) error {
+		called = append(called, "detach volume")
+		return fmt.Errorf("failed to get LUN: %w", ErrLunNotFound)
+	})
+	client.addPending(SideRemote, "test", func(ctx context.Context) error {
+		called = append(called, "test")
+		return nil
+	})
+
+	recovered, err := client.Recover(context.Background())
+	if err != nil {
+		t.Fatalf("Recover return err: %s", err)
+	}
+	if !recovered || client.IsDegraded() {
+		t.Errorf("Recover must recover client if object of pending operation is not found")
+	}
+	if want := []string{"detach volume", "test"}; !reflect.DeepEqual(called, want) {
+		t.Errorf("Recover run %v, want %v", called, want)
+	}
+}
+
+func TestClient_DiscardPendingOperation(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.degraded = &degradedState{unreachable: SideRemote}
+
+	mux.HandleFunc("/sessions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": {"iBaseToken": "dummy_token", "deviceid": "xx"}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	client.addPending(SideRemote, "broken", func(ctx context.Context) error {
+		return errors.New("permanent error")
+	})
+	client.addPending(SideRemote, "test", func(ctx context.Context) error {
+		return nil
+	})
+
+	recovered, err := client.Recover(context.Background())
+	if err == nil || recovered {
+		t.Fatalf("Recover must return err if pending operation is failed")
+	}
+
+	description, err := client.DiscardPendingOperation()
+	if err != nil {
+		t.Fatalf("DiscardPendingOperation return err: %s", err)
+	}
+	if description != "broken" {
+		t.Errorf("DiscardPendingOperation discard %s, want broken", description)
+	}
+
+	recovered, err = client.Recover(context.Background())
+	if err != nil {
+		t.Fatalf("Recover return err: %s", err)
+	}
+	if !recovered || client.IsDegraded() {
+		t.Errorf("Recover must recover client after discard")
+	}
+
+	if _, err := client.DiscardPendingOperation(); err == nil {
+		t.Errorf("DiscardPendingOperation must return err if pending operation is empty")
+	}
+}
+
+func TestClient_WatchRecovery_LogSameErrorOnce(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+	client.degraded = &degradedState{unreachable: SideRemote}
+
+	var buf bytes.Buffer
+	client.Logger = log.New(&buf, "", 0)
+
+	// POST /sessions is not handled, so Recover return same err per tick
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := client.WatchRecovery(ctx, 5*time.Millisecond)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("WatchRecovery return %v, want %s", err, context.DeadlineExceeded)
+	}
+
+	if n := strings.Count(buf.String(), "failed to recover"); n != 1 {
+		t.Errorf("WatchRecovery log error %d times, want 1:\n%s", n, buf.String())
+	}
+}
+
+func TestClient_runBothDevices_BothUnreachable(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+	client.degraded = &degradedState{}
+
+	// GET /system/ is not handled, so both devices are unreachable
+	err := client.runBothDevices(context.Background(), "test",
+		func(ctx context.Context) error { return errors.New("connection refused") },
+		func(ctx context.Context) error { return errors.New("connection refused") },
+	)
+	if err == nil {
+		t.Fatalf("runBothDevices must return err if both devices are unreachable")
+	}
+	if client.IsDegraded() {
+		t.Errorf("runBothDevices must not mark degraded if both devices are unreachable")
+	}
+	if ops := client.PendingOperations(); len(ops) != 0 {
+		t.Errorf("PendingOperations return %v, want empty", ops)
+	}
+}
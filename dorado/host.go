@@ -6,6 +6,7 @@ import (
 	"crypto/md5"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 )
@@ -79,8 +80,97 @@ func (d *Device) GetHost(ctx context.Context, hostID int) (*Host, error) {
 	return host, nil
 }
 
+// HostOption is optional parameter of host.
+type HostOption struct {
+	// OperationSystem is OS type of host (ex: OSLinux, OSVMwareESX).
+	// OS type of host is not changed if nil.
+	OperationSystem *int
+
+	// AccessMode set multipath and ALUA parameter to initiators of host if set.
+	// host object has no access mode, it is applied to initiators when attach.
+	AccessMode *AccessMode
+}
+
+// Validate check value of HostOption
+func (ho *HostOption) Validate() error {
+	if ho.OperationSystem != nil {
+		switch *ho.OperationSystem {
+		case OSLinux, OSWindows, OSSolaris, OSHPUX, OSAIX, OSXenServer, OSMacOS, OSVMwareESX, OSWindowsServer2012:
+		default:
+			return fmt.Errorf("invalid operation system: %d", *ho.OperationSystem)
+		}
+	}
+
+	if ho.AccessMode != nil {
+		if err := ho.AccessMode.Validate(); err != nil {
+			return fmt.Errorf("invalid access mode: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// needUpdate return true if OS type of host is different from option.
+func (ho *HostOption) needUpdate(host *Host) bool {
+	return ho != nil && ho.OperationSystem != nil && host.OPERATIONSYSTEM != strconv.Itoa(*ho.OperationSystem)
+}
+
+// GetAssociateHosts get host objects that associated object (ex: hostgroup, LUN)
+func (d *Device) GetAssociateHosts(ctx context.Context, query *SearchQuery) ([]Host, error) {
+	spath := "/host/associate"
+
+	req, err := d.newRequest(ctx, "GET", spath, nil)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+	req = AddSearchQuery(req, query)
+
+	var hosts []Host
+	if err = d.requestWithRetry(req, &hosts, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return hosts, nil
+}
+
+// GetHostGroupAssociatedHosts get host objects that associated hostgroup.
+func (d *Device) GetHostGroupAssociatedHosts(ctx context.Context, hostgroupID int) ([]Host, error) {
+	query := &SearchQuery{
+		AssociateObjType: strconv.Itoa(TypeHostGroup),
+		AssociateObjID:   strconv.Itoa(hostgroupID),
+	}
+
+	return d.GetAssociateHosts(ctx, query)
+}
+
+// GetLUNAssociatedHosts get host objects that LUN is mapped.
+func (d *Device) GetLUNAssociatedHosts(ctx context.Context, lunID int) ([]Host, error) {
+	query := &SearchQuery{
+		AssociateObjType: strconv.Itoa(TypeLUN),
+		AssociateObjID:   strconv.Itoa(lunID),
+	}
+
+	return d.GetAssociateHosts(ctx, query)
+}
+
 // CreateHost create host object.
 func (d *Device) CreateHost(ctx context.Context, hostname string) (*Host, error) {
+	return d.CreateHostWithOption(ctx, hostname, nil)
+}
+
+// CreateHostWithOption create host object with HostOption.
+// OS type is Linux if not set. AccessMode in opts is not used, it is applied to initiators when attach.
+func (d *Device) CreateHostWithOption(ctx context.Context, hostname string, opts *HostOption) (*Host, error) {
+	operationSystem := OSLinux
+	if opts != nil {
+		if err := opts.Validate(); err != nil {
+			return nil, fmt.Errorf("failed to validate host option: %w", err)
+		}
+		if opts.OperationSystem != nil {
+			operationSystem = *opts.OperationSystem
+		}
+	}
+
 	spath := "/host"
 	param := struct {
 		NAME            string `json:"NAME"`
@@ -90,7 +180,7 @@ func (d *Device) CreateHost(ctx context.Context, hostname string) (*Host, error)
 	}{
 		NAME:            encodeHostName(hostname),
 		TYPE:            strconv.Itoa(TypeHost),
-		OPERATIONSYSTEM: "0",
+		OPERATIONSYSTEM: strconv.Itoa(operationSystem),
 		DESCRIPTION:     hostname,
 	}
 	jb, err := json.Marshal(param)
@@ -110,6 +200,43 @@ func (d *Device) CreateHost(ctx context.Context, hostname string) (*Host, error)
 	return host, nil
 }
 
+// UpdateHost update OS type of host object. OperationSystem in opts is required.
+// AccessMode in opts is not used, please use SetInitiatorAccessMode to initiators of host.
+func (d *Device) UpdateHost(ctx context.Context, hostID int, opts HostOption) (*Host, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate host option: %w", err)
+	}
+	if opts.OperationSystem == nil {
+		return nil, errors.New("OS type of host is not set")
+	}
+
+	spath := fmt.Sprintf("/host/%d", hostID)
+	param := struct {
+		TYPE            string `json:"TYPE"`
+		ID              string `json:"ID"`
+		OPERATIONSYSTEM string `json:"OPERATIONSYSTEM"`
+	}{
+		TYPE:            strconv.Itoa(TypeHost),
+		ID:              strconv.Itoa(hostID),
+		OPERATIONSYSTEM: strconv.Itoa(*opts.OperationSystem),
+	}
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+	req, err := d.newRequest(ctx, "PUT", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	host := &Host{}
+	if err = d.requestWithRetry(req, host, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return host, nil
+}
+
 // DeleteHost delete host object.
 func (d *Device) DeleteHost(ctx context.Context, hostID int) error {
 	spath := fmt.Sprintf("/host/%d", hostID)
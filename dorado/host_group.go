@@ -12,7 +12,7 @@ import (
 
 // HostGroup is object of multiple host.
 // storage - host mapping must have a host group.
-// host group has only one host under our usage.
+// host group has only one host under our usage, except cluster (see Cluster).
 type HostGroup struct {
 	DESCRIPTION       string `json:"DESCRIPTION"`
 	ID                int    `json:"ID,string"`
@@ -0,0 +1,91 @@
+package dorado
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// HostLUNIDMismatch is host that see different host LUN ID of a volume between local and remote device.
+// host LUN ID is -1 if volume is not attached to host in the device.
+type HostLUNIDMismatch struct {
+	HostName        string
+	LocalHostLUNID  int
+	RemoteHostLUNID int
+}
+
+// GetHostLUNIDMismatches get hosts that host LUN ID of HyperMetroPair is different between local and remote device.
+// hosts are compared by host name.
+func (c *Client) GetHostLUNIDMismatches(ctx context.Context, hyperMetroPairID string) ([]HostLUNIDMismatch, error) {
+	if err := c.requireBothDevices(ctx); err != nil {
+		return nil, err
+	}
+
+	volume, err := c.GetHyperMetroPair(ctx, hyperMetroPairID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get volume information: %w", err)
+	}
+
+	localIDs, err := c.LocalDevice.getHostLUNIDsByHostName(ctx, volume.LOCALOBJID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get host LUN IDs in Local Device: %w", err)
+	}
+	remoteIDs, err := c.RemoteDevice.getHostLUNIDsByHostName(ctx, volume.REMOTEOBJID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get host LUN IDs in Remote Device: %w", err)
+	}
+
+	hostNames := map[string]bool{}
+	for name := range localIDs {
+		hostNames[name] = true
+	}
+	for name := range remoteIDs {
+		hostNames[name] = true
+	}
+
+	var mismatches []HostLUNIDMismatch
+	for name := range hostNames {
+		localID, ok := localIDs[name]
+		if !ok {
+			localID = -1
+		}
+		remoteID, ok := remoteIDs[name]
+		if !ok {
+			remoteID = -1
+		}
+
+		if localID != remoteID {
+			mismatches = append(mismatches, HostLUNIDMismatch{
+				HostName:        name,
+				LocalHostLUNID:  localID,
+				RemoteHostLUNID: remoteID,
+			})
+		}
+	}
+	sort.Slice(mismatches, func(i, j int) bool {
+		return mismatches[i].HostName < mismatches[j].HostName
+	})
+
+	return mismatches, nil
+}
+
+// getHostLUNIDsByHostName get host LUN ID of LUN for each host that LUN is mapped.
+// return map of host name to host LUN ID.
+func (d *Device) getHostLUNIDsByHostName(ctx context.Context, lunID int) (map[string]int, error) {
+	hosts, err := d.GetLUNAssociatedHosts(ctx, lunID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hosts: %w", err)
+	}
+
+	hostLUNIDs := map[string]int{}
+	for _, host := range hosts {
+		hostLUNID, err := d.GetHostLUNID(ctx, lunID, host.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get host LUN ID (host: %s): %w", host.NAME, err)
+		}
+
+		hostLUNIDs[host.NAME] = hostLUNID
+	}
+
+	return hostLUNIDs, nil
+}
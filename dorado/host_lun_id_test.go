@@ -0,0 +1,53 @@
+package dorado
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestClient_GetHostLUNIDMismatches(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	// local and remote device is the same test server, so LUN ID decide response
+	mux.HandleFunc("/HyperMetroPair/e4c2d1eaf02c0001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "e4c2d1eaf02c0001", "LOCALOBJID": "216", "REMOTEOBJID": "514", "TYPE": 15361}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/host/associate", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("ASSOCIATEOBJID") {
+		case "216":
+			fmt.Fprint(w, `{"data": [{"ID": "1", "NAME": "w-cn0001", "TYPE": 21}, {"ID": "2", "NAME": "w-cn0002", "TYPE": 21}], "error": {"code": 0, "description": "0"}}`)
+		case "514":
+			fmt.Fprint(w, `{"data": [{"ID": "1", "NAME": "w-cn0001", "TYPE": 21}, {"ID": "3", "NAME": "w-cn0003", "TYPE": 21}], "error": {"code": 0, "description": "0"}}`)
+		}
+	})
+	mux.HandleFunc("/lun/associate", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("ASSOCIATEOBJID") {
+		case "1":
+			fmt.Fprint(w, `{"data": [{"ID": "216", "ASSOCIATEMETADATA": "{\"HostLUNID\":1}"}, {"ID": "514", "ASSOCIATEMETADATA": "{\"HostLUNID\":1}"}], "error": {"code": 0, "description": "0"}}`)
+		case "2":
+			fmt.Fprint(w, `{"data": [{"ID": "216", "ASSOCIATEMETADATA": "{\"HostLUNID\":2}"}], "error": {"code": 0, "description": "0"}}`)
+		case "3":
+			fmt.Fprint(w, `{"data": [{"ID": "514", "ASSOCIATEMETADATA": "{\"HostLUNID\":3}"}], "error": {"code": 0, "description": "0"}}`)
+		}
+	})
+
+	mismatches, err := client.GetHostLUNIDMismatches(context.Background(), "e4c2d1eaf02c0001")
+	if err != nil {
+		t.Fatalf("GetHostLUNIDMismatches return err: %s", err)
+	}
+
+	want := []HostLUNIDMismatch{
+		{HostName: "w-cn0002", LocalHostLUNID: 2, RemoteHostLUNID: -1},
+		{HostName: "w-cn0003", LocalHostLUNID: -1, RemoteHostLUNID: 3},
+	}
+	if !reflect.DeepEqual(mismatches, want) {
+		t.Errorf("GetHostLUNIDMismatches return %+v, want %+v", mismatches, want)
+	}
+}
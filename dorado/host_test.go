@@ -2,6 +2,7 @@ package dorado
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -107,3 +108,55 @@ func TestDevice_GetHosts(t *testing.T) {
 		t.Errorf("GetHosts return %+v, want %+v", hosts, want)
 	}
 }
+
+func TestDevice_CreateHostWithOption(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/host", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var got map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+		if got["OPERATIONSYSTEM"] != "7" {
+			t.Errorf("OPERATIONSYSTEM is %s, want 7", got["OPERATIONSYSTEM"])
+		}
+		fmt.Fprint(w, `{"data": {"ID": "1", "NAME": "esxi01", "OPERATIONSYSTEM": "7", "TYPE": 21}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	esx := OSVMwareESX
+	host, err := client.LocalDevice.CreateHostWithOption(context.Background(), "esxi01", &HostOption{OperationSystem: &esx})
+	if err != nil {
+		t.Fatalf("CreateHostWithOption return err: %s", err)
+	}
+	if host.OPERATIONSYSTEM != "7" {
+		t.Errorf("CreateHostWithOption return %+v, want OPERATIONSYSTEM 7", host)
+	}
+
+	invalid := 100
+	_, err = client.LocalDevice.CreateHostWithOption(context.Background(), "esxi01", &HostOption{OperationSystem: &invalid})
+	if err == nil {
+		t.Errorf("CreateHostWithOption must return err if invalid operation system")
+	}
+}
+
+func TestHostOption_needUpdate(t *testing.T) {
+	linux, esx := OSLinux, OSVMwareESX
+	host := &Host{ID: 1, OPERATIONSYSTEM: "7"}
+
+	tests := []struct {
+		opts *HostOption
+		want bool
+	}{
+		{opts: nil, want: false},
+		{opts: &HostOption{AccessMode: &AccessMode{MultipathType: MultipathTypeDefault}}, want: false},
+		{opts: &HostOption{OperationSystem: &esx}, want: false},
+		{opts: &HostOption{OperationSystem: &linux}, want: true},
+	}
+	for _, test := range tests {
+		if got := test.opts.needUpdate(host); got != test.want {
+			t.Errorf("needUpdate(%+v) = %t, want %t", test.opts, got, test.want)
+		}
+	}
+}
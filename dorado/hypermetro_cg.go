@@ -0,0 +1,398 @@
+package dorado
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// HyperMetroConsistencyGroup is consistency group of HyperMetroPair.
+// pairs in a group keep write-order consistency when the link between devices is failed.
+type HyperMetroConsistencyGroup struct {
+	DESCRIPTION         string `json:"DESCRIPTION"`
+	DOMAINID            string `json:"DOMAINID"`
+	DOMAINNAME          string `json:"DOMAINNAME"`
+	HEALTHSTATUS        string `json:"HEALTHSTATUS"`
+	ID                  string `json:"ID"`
+	ISPRIMARY           string `json:"ISPRIMARY"`
+	LOCALDATASTATE      string `json:"LOCALDATASTATE"`
+	NAME                string `json:"NAME"`
+	PRIORITYSTATIONTYPE string `json:"PRIORITYSTATIONTYPE"`
+	RECOVERYPOLICY      string `json:"RECOVERYPOLICY"`
+	REMOTEDATASTATE     string `json:"REMOTEDATASTATE"`
+	RUNNINGSTATUS       string `json:"RUNNINGSTATUS"`
+	SPEED               string `json:"SPEED"`
+	SYNCDIRECTION       string `json:"SYNCDIRECTION"`
+	TYPE                int    `json:"TYPE"`
+}
+
+// HyperMetroConsistencyGroupParam is parameter of CreateHyperMetroConsistencyGroup
+type HyperMetroConsistencyGroupParam struct {
+	NAME                string `json:"NAME"`
+	DESCRIPTION         string `json:"DESCRIPTION"`
+	DOMAINID            string `json:"DOMAINID"`
+	RECOVERYPOLICY      string `json:"RECOVERYPOLICY"`
+	SPEED               string `json:"SPEED"`
+	PRIORITYSTATIONTYPE string `json:"PRIORITYSTATIONTYPE"`
+}
+
+// GetHyperMetroConsistencyGroups get HyperMetro consistency groups by query
+func (c *Client) GetHyperMetroConsistencyGroups(ctx context.Context, query *SearchQuery) ([]HyperMetroConsistencyGroup, error) {
+	spath := "/HyperMetro_ConsistentGroup"
+	d, isRemote := c.hyperMetroDevice()
+
+	req, err := d.newRequest(ctx, "GET", spath, nil)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+	req = AddSearchQuery(req, query)
+
+	var groups []HyperMetroConsistencyGroup
+	if err = d.requestWithRetry(req, &groups, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	if len(groups) == 0 {
+		return nil, ErrHyperMetroConsistencyGroupNotFound
+	}
+	if isRemote {
+		for i := range groups {
+			groups[i].ISPRIMARY = flipBoolString(groups[i].ISPRIMARY)
+		}
+	}
+
+	return groups, nil
+}
+
+// GetHyperMetroConsistencyGroup get HyperMetro consistency group by id
+func (c *Client) GetHyperMetroConsistencyGroup(ctx context.Context, cgID string) (*HyperMetroConsistencyGroup, error) {
+	spath := fmt.Sprintf("/HyperMetro_ConsistentGroup/%s", cgID)
+	d, isRemote := c.hyperMetroDevice()
+
+	req, err := d.newRequest(ctx, "GET", spath, nil)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	group := &HyperMetroConsistencyGroup{}
+	if err = d.requestWithRetry(req, group, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+	if isRemote {
+		group.ISPRIMARY = flipBoolString(group.ISPRIMARY)
+	}
+
+	return group, nil
+}
+
+// CreateHyperMetroConsistencyGroup create HyperMetro consistency group. local device is preferred site of group.
+func (c *Client) CreateHyperMetroConsistencyGroup(ctx context.Context, name, hyperMetroDomainID string) (*HyperMetroConsistencyGroup, error) {
+	spath := "/HyperMetro_ConsistentGroup"
+	d, isRemote := c.hyperMetroDevice()
+
+	priorityStation := PriorityStationPreferred
+	if isRemote {
+		priorityStation = PriorityStationNonPreferred
+	}
+	param := HyperMetroConsistencyGroupParam{
+		NAME:                name,
+		DESCRIPTION:         name,
+		DOMAINID:            hyperMetroDomainID,
+		RECOVERYPOLICY:      strconv.Itoa(RecoveryPolicyAutomatic),
+		SPEED:               strconv.Itoa(SpeedMedium),
+		PRIORITYSTATIONTYPE: strconv.Itoa(priorityStation),
+	}
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+
+	req, err := d.newRequest(ctx, "POST", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	group := &HyperMetroConsistencyGroup{}
+	if err = d.requestWithRetry(req, group, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+	if isRemote {
+		group.ISPRIMARY = flipBoolString(group.ISPRIMARY)
+	}
+
+	return group, nil
+}
+
+// UpdateHyperMetroConsistencyGroup update name and description of HyperMetro consistency group.
+func (c *Client) UpdateHyperMetroConsistencyGroup(ctx context.Context, cgID, name, description string) error {
+	spath := fmt.Sprintf("/HyperMetro_ConsistentGroup/%s", cgID)
+	param := struct {
+		ID          string `json:"ID"`
+		TYPE        string `json:"TYPE"`
+		NAME        string `json:"NAME,omitempty"`
+		DESCRIPTION string `json:"DESCRIPTION,omitempty"`
+	}{
+		ID:          cgID,
+		TYPE:        strconv.Itoa(TypeHyperMetroConsistencyGroup),
+		NAME:        name,
+		DESCRIPTION: description,
+	}
+
+	return c.putHyperMetroConsistencyGroup(ctx, spath, param)
+}
+
+// DeleteHyperMetroConsistencyGroup delete HyperMetro consistency group.
+// must be remove all HyperMetroPairs from group before call this method.
+func (c *Client) DeleteHyperMetroConsistencyGroup(ctx context.Context, cgID string) error {
+	spath := fmt.Sprintf("/HyperMetro_ConsistentGroup/%s", cgID)
+	d, _ := c.hyperMetroDevice()
+
+	req, err := d.newRequest(ctx, "DELETE", spath, nil)
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// AddHyperMetroPairToConsistencyGroup add HyperMetroPair to consistency group.
+// group and pair must be suspended, please use AddVolumesToConsistencyGroup.
+func (c *Client) AddHyperMetroPairToConsistencyGroup(ctx context.Context, cgID, hyperMetroPairID string) error {
+	spath := "/hyperMetro/associate/pair"
+	param := struct {
+		ID               string `json:"ID"`
+		TYPE             string `json:"TYPE"`
+		ASSOCIATEOBJID   string `json:"ASSOCIATEOBJID"`
+		ASSOCIATEOBJTYPE string `json:"ASSOCIATEOBJTYPE"`
+	}{
+		ID:               cgID,
+		TYPE:             strconv.Itoa(TypeHyperMetroConsistencyGroup),
+		ASSOCIATEOBJID:   hyperMetroPairID,
+		ASSOCIATEOBJTYPE: strconv.Itoa(TypeHyperMetroPair),
+	}
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+
+	req, err := c.LocalDevice.newRequest(ctx, "POST", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = c.LocalDevice.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// RemoveHyperMetroPairFromConsistencyGroup remove HyperMetroPair from consistency group.
+// group must be suspended.
+func (c *Client) RemoveHyperMetroPairFromConsistencyGroup(ctx context.Context, cgID, hyperMetroPairID string) error {
+	spath := "/hyperMetro/associate/pair"
+
+	req, err := c.LocalDevice.newRequest(ctx, "DELETE", spath, nil)
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+	param := &AssociateParam{
+		ID:               cgID,
+		TYPE:             strconv.Itoa(TypeHyperMetroConsistencyGroup),
+		ASSOCIATEOBJID:   hyperMetroPairID,
+		ASSOCIATEOBJTYPE: TypeHyperMetroPair,
+	}
+	req = AddAssociateParam(req, param)
+
+	var i interface{} // this endpoint return N/A
+	if err = c.LocalDevice.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// SuspendHyperMetroConsistencyGroup suspend HyperMetro sync of all pairs in group.
+func (c *Client) SuspendHyperMetroConsistencyGroup(ctx context.Context, cgID string) error {
+	return c.operateHyperMetroConsistencyGroup(ctx, "/HyperMetro_ConsistentGroup/stop", cgID)
+}
+
+// SyncHyperMetroConsistencyGroup start to sync HyperMetro of all pairs in group.
+func (c *Client) SyncHyperMetroConsistencyGroup(ctx context.Context, cgID string) error {
+	return c.operateHyperMetroConsistencyGroup(ctx, "/HyperMetro_ConsistentGroup/sync", cgID)
+}
+
+// SwitchHyperMetroConsistencyGroupPriority switch preferred site of group.
+func (c *Client) SwitchHyperMetroConsistencyGroupPriority(ctx context.Context, cgID string) error {
+	return c.operateHyperMetroConsistencyGroup(ctx, "/HyperMetro_ConsistentGroup/switch", cgID)
+}
+
+// ForceStartHyperMetroConsistencyGroup force start group in local device.
+// host can access to local LUNs of group while remote device is unavailable.
+func (c *Client) ForceStartHyperMetroConsistencyGroup(ctx context.Context, cgID string) error {
+	return c.operateHyperMetroConsistencyGroup(ctx, "/HyperMetro_ConsistentGroup/force_start", cgID)
+}
+
+func (c *Client) operateHyperMetroConsistencyGroup(ctx context.Context, spath, cgID string) error {
+	param := struct {
+		ID   string `json:"ID"`
+		TYPE string `json:"TYPE"`
+	}{
+		ID:   cgID,
+		TYPE: strconv.Itoa(TypeHyperMetroConsistencyGroup),
+	}
+
+	return c.putHyperMetroConsistencyGroup(ctx, spath, param)
+}
+
+func (c *Client) putHyperMetroConsistencyGroup(ctx context.Context, spath string, param interface{}) error {
+	d, _ := c.hyperMetroDevice()
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+
+	req, err := d.newRequest(ctx, "PUT", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// AddVolumesToConsistencyGroup add HyperMetroPairs to consistency group.
+// group is suspended while adding, and re-sync after added or failed to add.
+func (c *Client) AddVolumesToConsistencyGroup(ctx context.Context, cgID string, hyperMetroPairIDs []string) error {
+	if err := c.suspendConsistencyGroup(ctx, cgID); err != nil {
+		return err
+	}
+
+	for _, id := range hyperMetroPairIDs {
+		hmp, err := c.GetHyperMetroPair(ctx, id)
+		if err != nil {
+			c.resyncConsistencyGroup(ctx, cgID)
+			return fmt.Errorf("failed to get HyperMetroPair: %w", err)
+		}
+		if hmp.RUNNINGSTATUS != strconv.Itoa(StatusPause) {
+			err = c.SuspendHyperMetroPair(ctx, id)
+			if err != nil {
+				c.resyncConsistencyGroup(ctx, cgID)
+				return fmt.Errorf("failed to suspend HyperMetroPair: %w", err)
+			}
+		}
+
+		err = c.AddHyperMetroPairToConsistencyGroup(ctx, cgID, id)
+		if err != nil {
+			c.resyncConsistencyGroup(ctx, cgID, id)
+			return fmt.Errorf("failed to add HyperMetroPair to consistency group (ID: %s): %w", id, err)
+		}
+	}
+
+	if err := c.SyncHyperMetroConsistencyGroup(ctx, cgID); err != nil {
+		return fmt.Errorf("failed to sync HyperMetro consistency group: %w", err)
+	}
+
+	return nil
+}
+
+// suspendConsistencyGroup suspend group if it is not suspended.
+func (c *Client) suspendConsistencyGroup(ctx context.Context, cgID string) error {
+	group, err := c.GetHyperMetroConsistencyGroup(ctx, cgID)
+	if err != nil {
+		return fmt.Errorf("failed to get HyperMetro consistency group: %w", err)
+	}
+
+	if group.RUNNINGSTATUS != strconv.Itoa(StatusPause) {
+		if err := c.SuspendHyperMetroConsistencyGroup(ctx, cgID); err != nil {
+			return fmt.Errorf("failed to suspend HyperMetro consistency group: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// resyncConsistencyGroup re-sync group and HyperMetroPairs that are suspended but not added to group.
+func (c *Client) resyncConsistencyGroup(ctx context.Context, cgID string, hyperMetroPairIDs ...string) {
+	if err := c.SyncHyperMetroConsistencyGroup(ctx, cgID); err != nil {
+		c.Logger.Printf("failed to re-sync HyperMetro consistency group: %v", err)
+	}
+	for _, id := range hyperMetroPairIDs {
+		if err := c.SyncHyperMetroPair(ctx, id); err != nil {
+			c.Logger.Printf("failed to re-sync HyperMetroPair (ID: %s): %v", id, err)
+		}
+	}
+}
+
+// CreateVolumesInConsistencyGroup create blank HyperMetroPairs and add to consistency group.
+// created HyperMetroPairs are deleted if failed.
+func (c *Client) CreateVolumesInConsistencyGroup(ctx context.Context, names []uuid.UUID, capacityGB int, storagePoolName, hyperMetroDomainID, cgID string) ([]HyperMetroPair, error) {
+	var hyperMetroPairs []HyperMetroPair
+	var ids []string
+	for _, name := range names {
+		hmp, err := c.CreateVolumeRaw(ctx, name, capacityGB, storagePoolName, hyperMetroDomainID, nil)
+		if err != nil {
+			c.deleteVolumesInConsistencyGroup(ctx, cgID, ids)
+			return nil, fmt.Errorf("failed to create volume (name: %s): %w", name.String(), err)
+		}
+
+		hyperMetroPairs = append(hyperMetroPairs, *hmp)
+		ids = append(ids, hmp.ID)
+	}
+
+	err := c.AddVolumesToConsistencyGroup(ctx, cgID, ids)
+	if err != nil {
+		c.deleteVolumesInConsistencyGroup(ctx, cgID, ids)
+		return nil, fmt.Errorf("failed to add volumes to consistency group: %w", err)
+	}
+
+	return hyperMetroPairs, nil
+}
+
+// deleteVolumesInConsistencyGroup delete HyperMetroPairs, and remove from group before delete if added.
+// group is suspended while removing, and re-sync after removed.
+func (c *Client) deleteVolumesInConsistencyGroup(ctx context.Context, cgID string, hyperMetroPairIDs []string) {
+	suspended := false
+	defer func() {
+		if suspended {
+			c.resyncConsistencyGroup(ctx, cgID)
+		}
+	}()
+
+	for _, id := range hyperMetroPairIDs {
+		hmp, err := c.GetHyperMetroPair(ctx, id)
+		if err != nil {
+			c.Logger.Printf("failed to get HyperMetroPair (ID: %s): %v", id, err)
+			continue
+		}
+		if hmp.ISINCG == "true" {
+			if !suspended {
+				if err := c.suspendConsistencyGroup(ctx, cgID); err != nil {
+					c.Logger.Printf("failed to suspend HyperMetro consistency group: %v", err)
+				}
+				suspended = true
+			}
+			if err := c.RemoveHyperMetroPairFromConsistencyGroup(ctx, cgID, id); err != nil {
+				c.Logger.Printf("failed to remove HyperMetroPair from consistency group (ID: %s): %v", id, err)
+				continue
+			}
+		}
+		if err := c.DeleteVolume(ctx, id); err != nil {
+			c.Logger.Printf("failed to delete volume (ID: %s): %v", id, err)
+		}
+	}
+}
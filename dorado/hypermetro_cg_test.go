@@ -0,0 +1,183 @@
+package dorado
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestClient_GetHyperMetroConsistencyGroups(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/HyperMetro_ConsistentGroup", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w,
+			`
+{
+ "data": [
+        {
+            "DESCRIPTION": "cg01",
+            "DOMAINID": "e4c2d1eaf02c0100",
+            "DOMAINNAME": "shuanyu",
+            "HEALTHSTATUS": "1",
+            "ID": "e4c2d1eaf02c0002",
+            "ISPRIMARY": "true",
+            "LOCALDATASTATE": "1",
+            "NAME": "cg01",
+            "PRIORITYSTATIONTYPE": "0",
+            "RECOVERYPOLICY": "1",
+            "REMOTEDATASTATE": "1",
+            "RUNNINGSTATUS": "1",
+            "SPEED": "2",
+            "SYNCDIRECTION": "1",
+            "TYPE": 15364
+        }
+ ],
+ "error": {
+        "code": 0,
+        "description": "0"
+ }
+}`)
+	})
+
+	groups, err := client.GetHyperMetroConsistencyGroups(context.Background(), nil)
+	if err != nil {
+		t.Errorf("GetHyperMetroConsistencyGroups return err: %s", err)
+	}
+
+	want := []HyperMetroConsistencyGroup{
+		{
+			DESCRIPTION:         "cg01",
+			DOMAINID:            "e4c2d1eaf02c0100",
+			DOMAINNAME:          "shuanyu",
+			HEALTHSTATUS:        "1",
+			ID:                  "e4c2d1eaf02c0002",
+			ISPRIMARY:           "true",
+			LOCALDATASTATE:      "1",
+			NAME:                "cg01",
+			PRIORITYSTATIONTYPE: "0",
+			RECOVERYPOLICY:      "1",
+			REMOTEDATASTATE:     "1",
+			RUNNINGSTATUS:       "1",
+			SPEED:               "2",
+			SYNCDIRECTION:       "1",
+			TYPE:                TypeHyperMetroConsistencyGroup,
+		},
+	}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("GetHyperMetroConsistencyGroups return %+v, want %+v", groups, want)
+	}
+}
+
+func TestClient_AddVolumesToConsistencyGroup(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var calls []string
+	record := func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	}
+	mux.HandleFunc("/HyperMetro_ConsistentGroup/a0fc2a6a1e030001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "a0fc2a6a1e030001", "RUNNINGSTATUS": "1", "TYPE": 15364}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/HyperMetroPair/e4c2d1eaf02c0001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "e4c2d1eaf02c0001", "RUNNINGSTATUS": "1", "TYPE": 15361}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/HyperMetro_ConsistentGroup/stop", record)
+	mux.HandleFunc("/HyperMetro_ConsistentGroup/sync", record)
+	mux.HandleFunc("/HyperMetroPair/disable_hcpair", record)
+	mux.HandleFunc("/hyperMetro/associate/pair", record)
+
+	if err := client.AddVolumesToConsistencyGroup(context.Background(), "a0fc2a6a1e030001", []string{"e4c2d1eaf02c0001"}); err != nil {
+		t.Fatalf("AddVolumesToConsistencyGroup return err: %s", err)
+	}
+
+	want := []string{
+		"PUT /HyperMetro_ConsistentGroup/stop",
+		"PUT /HyperMetroPair/disable_hcpair",
+		"POST /hyperMetro/associate/pair",
+		"PUT /HyperMetro_ConsistentGroup/sync",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("AddVolumesToConsistencyGroup call %v, want %v", calls, want)
+	}
+}
+
+func TestClient_AddVolumesToConsistencyGroup_Resync(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var calls []string
+	record := func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	}
+	mux.HandleFunc("/HyperMetro_ConsistentGroup/a0fc2a6a1e030001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "a0fc2a6a1e030001", "RUNNINGSTATUS": "41", "TYPE": 15364}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/HyperMetroPair/e4c2d1eaf02c0001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "e4c2d1eaf02c0001", "RUNNINGSTATUS": "1", "TYPE": 15361}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/HyperMetro_ConsistentGroup/sync", record)
+	mux.HandleFunc("/HyperMetroPair/disable_hcpair", record)
+	mux.HandleFunc("/HyperMetroPair/synchronize_hcpair", record)
+	mux.HandleFunc("/hyperMetro/associate/pair", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 1077674242, "description": "The pair is in a consistency group."}}`)
+	})
+
+	if err := client.AddVolumesToConsistencyGroup(context.Background(), "a0fc2a6a1e030001", []string{"e4c2d1eaf02c0001"}); err == nil {
+		t.Fatalf("AddVolumesToConsistencyGroup must return err if failed to add")
+	}
+
+	want := []string{
+		"PUT /HyperMetroPair/disable_hcpair",
+		"POST /hyperMetro/associate/pair",
+		"PUT /HyperMetro_ConsistentGroup/sync",
+		"PUT /HyperMetroPair/synchronize_hcpair",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("AddVolumesToConsistencyGroup call %v, want %v", calls, want)
+	}
+}
+
+func TestClient_deleteVolumesInConsistencyGroup(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var calls []string
+	record := func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	}
+	mux.HandleFunc("/HyperMetro_ConsistentGroup/a0fc2a6a1e030001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "a0fc2a6a1e030001", "RUNNINGSTATUS": "1", "TYPE": 15364}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/HyperMetroPair/e4c2d1eaf02c0001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "e4c2d1eaf02c0001", "ISINCG": "true", "CGID": "a0fc2a6a1e030001", "LOCALOBJID": "216", "REMOTEOBJID": "514", "RUNNINGSTATUS": "1", "TYPE": 15361}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/HyperMetro_ConsistentGroup/stop", record)
+	mux.HandleFunc("/HyperMetro_ConsistentGroup/sync", record)
+	mux.HandleFunc("/hyperMetro/associate/pair", record)
+
+	client.deleteVolumesInConsistencyGroup(context.Background(), "a0fc2a6a1e030001", []string{"e4c2d1eaf02c0001"})
+
+	want := []string{
+		"PUT /HyperMetro_ConsistentGroup/stop",
+		"DELETE /hyperMetro/associate/pair",
+		"PUT /HyperMetro_ConsistentGroup/sync",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("deleteVolumesInConsistencyGroup call %v, want %v", calls, want)
+	}
+}
@@ -1,13 +1,13 @@
 package dorado
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
 )
 
-// NOTE(whywaita): implement only GET.
-// HyperMetroDomain is a few under our usage.
-
 // HyperMetroDomain is domain of HyperMetro
 type HyperMetroDomain struct {
 	CPSID          string `json:"CPSID"`
@@ -51,3 +51,214 @@ func (d *Device) GetHyperMetroDomains(ctx context.Context, query *SearchQuery) (
 
 	return hyperMetroDomains, nil
 }
+
+// GetHyperMetroDomain get HyperMetroDomain object by id in device.
+func (d *Device) GetHyperMetroDomain(ctx context.Context, domainID string) (*HyperMetroDomain, error) {
+	spath := fmt.Sprintf("/HyperMetroDomain/%s", domainID)
+
+	req, err := d.newRequest(ctx, "GET", spath, nil)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	hyperMetroDomain := &HyperMetroDomain{}
+	if err = d.requestWithRetry(req, hyperMetroDomain, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return hyperMetroDomain, nil
+}
+
+// HyperMetroDomainRemoteDevice is remote device of HyperMetroDomain
+type HyperMetroDomainRemoteDevice struct {
+	DevID   string `json:"devId"`
+	DevESN  string `json:"devESN,omitempty"`
+	DevName string `json:"devName,omitempty"`
+}
+
+// HyperMetroDomainParam is parameter of CreateHyperMetroDomain and UpdateHyperMetroDomain
+type HyperMetroDomainParam struct {
+	Name          string
+	Description   string
+	RemoteDevices []HyperMetroDomainRemoteDevice
+	// CPType is CPTypeStaticPriority or CPTypeQuorumServer.
+	CPType int
+	// QuorumServerID and StandbyQuorumServerID use only CPType is CPTypeQuorumServer.
+	QuorumServerID        string
+	StandbyQuorumServerID string
+}
+
+type hyperMetroDomainParam struct {
+	ID            string                         `json:"ID,omitempty"`
+	TYPE          string                         `json:"TYPE,omitempty"`
+	NAME          string                         `json:"NAME,omitempty"`
+	DESCRIPTION   string                         `json:"DESCRIPTION,omitempty"`
+	DOMAINTYPE    string                         `json:"DOMAINTYPE,omitempty"`
+	REMOTEDEVICES []HyperMetroDomainRemoteDevice `json:"REMOTEDEVICES,omitempty"`
+	CPTYPE        string                         `json:"CPTYPE,omitempty"`
+	CPSID         string                         `json:"CPSID,omitempty"`
+	STANDBYCPSID  string                         `json:"STANDBYCPSID,omitempty"`
+}
+
+// Validate validate parameter
+func (p *HyperMetroDomainParam) Validate() error {
+	switch p.CPType {
+	case CPTypeStaticPriority:
+		if p.QuorumServerID != "" || p.StandbyQuorumServerID != "" {
+			return fmt.Errorf("quorum server can't be set in static priority mode")
+		}
+	case CPTypeQuorumServer:
+		if p.QuorumServerID == "" {
+			return fmt.Errorf("quorum server is required in quorum server mode")
+		}
+		if p.QuorumServerID == p.StandbyQuorumServerID {
+			return fmt.Errorf("standby quorum server must be different from quorum server")
+		}
+	default:
+		return fmt.Errorf("invalid CPType: %d", p.CPType)
+	}
+
+	return nil
+}
+
+func (p *HyperMetroDomainParam) toParam() hyperMetroDomainParam {
+	return hyperMetroDomainParam{
+		NAME:          p.Name,
+		DESCRIPTION:   p.Description,
+		REMOTEDEVICES: p.RemoteDevices,
+		CPTYPE:        strconv.Itoa(p.CPType),
+		CPSID:         p.QuorumServerID,
+		STANDBYCPSID:  p.StandbyQuorumServerID,
+	}
+}
+
+// CreateHyperMetroDomain create HyperMetroDomain.
+func (c *Client) CreateHyperMetroDomain(ctx context.Context, param HyperMetroDomainParam) (*HyperMetroDomain, error) {
+	// HyperMetroDomain is a same value between a local device and a remote device.
+	return c.LocalDevice.CreateHyperMetroDomain(ctx, param)
+}
+
+// CreateHyperMetroDomain create HyperMetroDomain in device.
+func (d *Device) CreateHyperMetroDomain(ctx context.Context, param HyperMetroDomainParam) (*HyperMetroDomain, error) {
+	if err := param.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate parameter: %w", err)
+	}
+	if len(param.RemoteDevices) == 0 {
+		return nil, fmt.Errorf("remote devices is required")
+	}
+
+	spath := "/HyperMetroDomain"
+	p := param.toParam()
+	p.DOMAINTYPE = "1"
+	jb, err := json.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+	req, err := d.newRequest(ctx, "POST", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	hyperMetroDomain := &HyperMetroDomain{}
+	if err = d.requestWithRetry(req, hyperMetroDomain, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return hyperMetroDomain, nil
+}
+
+// UpdateHyperMetroDomain modify HyperMetroDomain.
+// RemoteDevices is ignored, remote devices of domain can't be changed.
+func (c *Client) UpdateHyperMetroDomain(ctx context.Context, domainID string, param HyperMetroDomainParam) error {
+	return c.LocalDevice.UpdateHyperMetroDomain(ctx, domainID, param)
+}
+
+// UpdateHyperMetroDomain modify HyperMetroDomain in device.
+func (d *Device) UpdateHyperMetroDomain(ctx context.Context, domainID string, param HyperMetroDomainParam) error {
+	if err := param.Validate(); err != nil {
+		return fmt.Errorf("failed to validate parameter: %w", err)
+	}
+
+	spath := fmt.Sprintf("/HyperMetroDomain/%s", domainID)
+	p := param.toParam()
+	p.ID = domainID
+	p.TYPE = strconv.Itoa(TypeHyperMetroDomain)
+	p.REMOTEDEVICES = nil
+	jb, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+	req, err := d.newRequest(ctx, "PUT", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// DeleteHyperMetroDomain delete HyperMetroDomain.
+// must be delete all HyperMetroPairs in domain before call this method.
+func (c *Client) DeleteHyperMetroDomain(ctx context.Context, domainID string) error {
+	return c.LocalDevice.DeleteHyperMetroDomain(ctx, domainID)
+}
+
+// DeleteHyperMetroDomain delete HyperMetroDomain in device.
+func (d *Device) DeleteHyperMetroDomain(ctx context.Context, domainID string) error {
+	spath := fmt.Sprintf("/HyperMetroDomain/%s", domainID)
+
+	req, err := d.newRequest(ctx, "DELETE", spath, nil)
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// HyperMetroDomainStatus is status of HyperMetroDomain include quorum links.
+type HyperMetroDomainStatus struct {
+	Domain HyperMetroDomain
+	// Quorum and StandbyQuorum is nil if not set.
+	Quorum        *QuorumServerStatus
+	StandbyQuorum *QuorumServerStatus
+}
+
+// IsQuorumReachable return true if any quorum server has link up.
+func (s *HyperMetroDomainStatus) IsQuorumReachable() bool {
+	return s.Quorum.IsLinkUp() || s.StandbyQuorum.IsLinkUp()
+}
+
+// GetHyperMetroDomainStatus get status of HyperMetroDomain in device.
+func (d *Device) GetHyperMetroDomainStatus(ctx context.Context, domainID string) (*HyperMetroDomainStatus, error) {
+	domain, err := d.GetHyperMetroDomain(ctx, domainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HyperMetroDomain: %w", err)
+	}
+
+	status := &HyperMetroDomainStatus{
+		Domain: *domain,
+	}
+	if domain.CPSID != "" {
+		status.Quorum, err = d.GetQuorumServerStatus(ctx, domain.CPSID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get quorum server status: %w", err)
+		}
+	}
+	if domain.STANDBYCPSID != "" {
+		status.StandbyQuorum, err = d.GetQuorumServerStatus(ctx, domain.STANDBYCPSID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get standby quorum server status: %w", err)
+		}
+	}
+
+	return status, nil
+}
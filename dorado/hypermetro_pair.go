@@ -19,6 +19,49 @@ type HyperMetroPairParam struct {
 	ISFIRSTSYNC     bool   `json:"ISFIRSTSYNC"`
 }
 
+// UpdateHyperMetroPairParam is parameter of update HyperMetroPair
+type UpdateHyperMetroPairParam struct {
+	ID             string `json:"ID"`
+	TYPE           string `json:"TYPE"`
+	RECOVERYPOLICY string `json:"RECOVERYPOLICY,omitempty"`
+	SPEED          string `json:"SPEED,omitempty"`
+	SYNCDIRECTION  string `json:"SYNCDIRECTION,omitempty"`
+}
+
+// HyperMetroPairState is RUNNINGSTATUS of HyperMetroPair
+type HyperMetroPairState int
+
+// HyperMetroPairStates
+const (
+	HyperMetroPairStateUnknown          HyperMetroPairState = 0
+	HyperMetroPairStateNormal           HyperMetroPairState = StatusNormal
+	HyperMetroPairStateSynchronizing    HyperMetroPairState = StatusSynchronizing
+	HyperMetroPairStateInvalid          HyperMetroPairState = StatusInvalid
+	HyperMetroPairStatePause            HyperMetroPairState = StatusPause
+	HyperMetroPairStateForcedStart      HyperMetroPairState = StatusForcedStart
+	HyperMetroPairStateToBeSynchronized HyperMetroPairState = StatusToBeSynchronized
+)
+
+// String return name of state
+func (s HyperMetroPairState) String() string {
+	switch s {
+	case HyperMetroPairStateNormal:
+		return "normal"
+	case HyperMetroPairStateSynchronizing:
+		return "synchronizing"
+	case HyperMetroPairStateInvalid:
+		return "invalid"
+	case HyperMetroPairStatePause:
+		return "pause"
+	case HyperMetroPairStateForcedStart:
+		return "forced start"
+	case HyperMetroPairStateToBeSynchronized:
+		return "to be synchronized"
+	default:
+		return fmt.Sprintf("unknown (%d)", int(s))
+	}
+}
+
 // HyperMetroPair is object of LUN (synced by HyperMetro)
 type HyperMetroPair struct {
 	CAPACITYBYTE             string `json:"CAPACITYBYTE"`
@@ -59,8 +102,9 @@ type HyperMetroPair struct {
 // GetHyperMetroPairs get HyperMetro objects by query
 func (c *Client) GetHyperMetroPairs(ctx context.Context, query *SearchQuery) ([]HyperMetroPair, error) {
 	spath := "/HyperMetroPair"
+	d, isRemote := c.hyperMetroDevice()
 
-	req, err := c.LocalDevice.newRequest(ctx, "GET", spath, nil)
+	req, err := d.newRequest(ctx, "GET", spath, nil)
 	if err != nil {
 		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
 	}
@@ -72,13 +116,18 @@ func (c *Client) GetHyperMetroPairs(ctx context.Context, query *SearchQuery) ([]
 	req = AddSearchQuery(req, query)
 
 	var hyperMetroPairs []HyperMetroPair
-	if err = c.LocalDevice.requestWithRetry(req, &hyperMetroPairs, DefaultHTTPRetryCount); err != nil {
+	if err = d.requestWithRetry(req, &hyperMetroPairs, DefaultHTTPRetryCount); err != nil {
 		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
 	}
 
 	if len(hyperMetroPairs) == 0 {
 		return nil, ErrHyperMetroPairNotFound
 	}
+	if isRemote {
+		for i := range hyperMetroPairs {
+			hyperMetroPairs[i].toLocalView()
+		}
+	}
 
 	return hyperMetroPairs, nil
 }
@@ -86,16 +135,20 @@ func (c *Client) GetHyperMetroPairs(ctx context.Context, query *SearchQuery) ([]
 // GetHyperMetroPair get HyperMetro object by id
 func (c *Client) GetHyperMetroPair(ctx context.Context, hyperMetroPairID string) (*HyperMetroPair, error) {
 	spath := fmt.Sprintf("/HyperMetroPair/%s", hyperMetroPairID)
+	d, isRemote := c.hyperMetroDevice()
 
-	req, err := c.LocalDevice.newRequest(ctx, "GET", spath, nil)
+	req, err := d.newRequest(ctx, "GET", spath, nil)
 	if err != nil {
 		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
 	}
 
 	hyperMetroPair := &HyperMetroPair{}
-	if err = c.LocalDevice.requestWithRetry(req, hyperMetroPair, DefaultHTTPRetryCount); err != nil {
+	if err = d.requestWithRetry(req, hyperMetroPair, DefaultHTTPRetryCount); err != nil {
 		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
 	}
+	if isRemote {
+		hyperMetroPair.toLocalView()
+	}
 
 	return hyperMetroPair, nil
 }
@@ -150,7 +203,112 @@ func (c *Client) DeleteHyperMetroPair(ctx context.Context, hyperMetroPairID stri
 
 // SuspendHyperMetroPair suspend HyperMetro sync.
 func (c *Client) SuspendHyperMetroPair(ctx context.Context, hyperMetroPairID string) error {
-	spath := "/HyperMetroPair/disable_hcpair"
+	return c.operateHyperMetroPair(ctx, "/HyperMetroPair/disable_hcpair", hyperMetroPairID)
+}
+
+// SyncHyperMetroPair start to sync HyperMetro.
+func (c *Client) SyncHyperMetroPair(ctx context.Context, hyperMetroPairID string) error {
+	return c.operateHyperMetroPair(ctx, "/HyperMetroPair/synchronize_hcpair", hyperMetroPairID)
+}
+
+// ForceStartHyperMetroPair force start HyperMetroPair in local device.
+// host can access to local LUN while remote device is unavailable.
+// pair must be paused or to be synchronized, a running pair is refused to prevent split-brain.
+func (c *Client) ForceStartHyperMetroPair(ctx context.Context, hyperMetroPairID string) error {
+	hmp, err := c.GetHyperMetroPair(ctx, hyperMetroPairID)
+	if err != nil {
+		return fmt.Errorf("failed to get HyperMetroPair: %w", err)
+	}
+	state := hmp.State()
+	switch state {
+	case HyperMetroPairStateForcedStart:
+		return nil
+	case HyperMetroPairStatePause, HyperMetroPairStateToBeSynchronized:
+	default:
+		return fmt.Errorf("can't force start HyperMetroPair in %s: %w", state, ErrHyperMetroPairUnsafeTransition)
+	}
+
+	return c.operateHyperMetroPair(ctx, "/HyperMetroPair/force_start_hcpair", hyperMetroPairID)
+}
+
+// SwitchHyperMetroPairPriority switch preferred site of HyperMetroPair.
+// pair must be normal or paused.
+func (c *Client) SwitchHyperMetroPairPriority(ctx context.Context, hyperMetroPairID string) error {
+	hmp, err := c.GetHyperMetroPair(ctx, hyperMetroPairID)
+	if err != nil {
+		return fmt.Errorf("failed to get HyperMetroPair: %w", err)
+	}
+	state := hmp.State()
+	switch state {
+	case HyperMetroPairStateNormal, HyperMetroPairStatePause:
+	default:
+		return fmt.Errorf("can't switch priority of HyperMetroPair in %s: %w", state, ErrHyperMetroPairUnsafeTransition)
+	}
+
+	return c.operateHyperMetroPair(ctx, "/HyperMetroPair/switch_hcpair", hyperMetroPairID)
+}
+
+// SetHyperMetroPairRecoveryPolicy set RECOVERYPOLICY (RecoveryPolicyAutomatic or RecoveryPolicyManual).
+func (c *Client) SetHyperMetroPairRecoveryPolicy(ctx context.Context, hyperMetroPairID string, policy int) error {
+	if policy != RecoveryPolicyAutomatic && policy != RecoveryPolicyManual {
+		return fmt.Errorf("invalid recovery policy: %d", policy)
+	}
+
+	return c.updateHyperMetroPair(ctx, hyperMetroPairID, &UpdateHyperMetroPairParam{
+		RECOVERYPOLICY: strconv.Itoa(policy),
+	})
+}
+
+// SetHyperMetroPairSpeed set sync SPEED (SpeedLow to SpeedHighest).
+func (c *Client) SetHyperMetroPairSpeed(ctx context.Context, hyperMetroPairID string, speed int) error {
+	if speed < SpeedLow || speed > SpeedHighest {
+		return fmt.Errorf("invalid speed: %d", speed)
+	}
+
+	return c.updateHyperMetroPair(ctx, hyperMetroPairID, &UpdateHyperMetroPairParam{
+		SPEED: strconv.Itoa(speed),
+	})
+}
+
+// GetHyperMetroPairSyncDirection get SYNCDIRECTION of HyperMetroPair.
+func (c *Client) GetHyperMetroPairSyncDirection(ctx context.Context, hyperMetroPairID string) (int, error) {
+	hmp, err := c.GetHyperMetroPair(ctx, hyperMetroPairID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get HyperMetroPair: %w", err)
+	}
+
+	direction, err := strconv.Atoi(hmp.SYNCDIRECTION)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse SYNCDIRECTION (%s): %w", hmp.SYNCDIRECTION, err)
+	}
+
+	return direction, nil
+}
+
+// SetHyperMetroPairSyncDirection set SYNCDIRECTION of HyperMetroPair.
+// pair must be not syncing, a running pair is refused to prevent to overwrite newer data.
+func (c *Client) SetHyperMetroPairSyncDirection(ctx context.Context, hyperMetroPairID string, direction int) error {
+	if direction != SyncDirectionLocalToRemote && direction != SyncDirectionRemoteToLocal {
+		return fmt.Errorf("invalid sync direction: %d", direction)
+	}
+
+	hmp, err := c.GetHyperMetroPair(ctx, hyperMetroPairID)
+	if err != nil {
+		return fmt.Errorf("failed to get HyperMetroPair: %w", err)
+	}
+	state := hmp.State()
+	switch state {
+	case HyperMetroPairStatePause, HyperMetroPairStateForcedStart, HyperMetroPairStateToBeSynchronized:
+	default:
+		return fmt.Errorf("can't change sync direction of HyperMetroPair in %s: %w", state, ErrHyperMetroPairUnsafeTransition)
+	}
+
+	return c.updateHyperMetroPair(ctx, hyperMetroPairID, &UpdateHyperMetroPairParam{
+		SYNCDIRECTION: strconv.Itoa(direction),
+	})
+}
+
+func (c *Client) operateHyperMetroPair(ctx context.Context, spath, hyperMetroPairID string) error {
 	param := struct {
 		ID   string `json:"ID"`
 		TYPE string `json:"TYPE"`
@@ -158,48 +316,55 @@ func (c *Client) SuspendHyperMetroPair(ctx context.Context, hyperMetroPairID str
 		ID:   hyperMetroPairID,
 		TYPE: strconv.Itoa(TypeHyperMetroPair),
 	}
+	d, _ := c.hyperMetroDevice()
 	jb, err := json.Marshal(param)
 	if err != nil {
 		return fmt.Errorf(ErrCreatePostValue+": %w", err)
 	}
 
-	req, err := c.LocalDevice.newRequest(ctx, "PUT", spath, bytes.NewBuffer(jb))
+	req, err := d.newRequest(ctx, "PUT", spath, bytes.NewBuffer(jb))
 	if err != nil {
 		return fmt.Errorf(ErrCreateRequest+": %w", err)
 	}
 
 	var i interface{} // this endpoint return N/A
-	if err = c.LocalDevice.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
 		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
 	}
 
 	return nil
 }
 
-// SyncHyperMetroPair start to sync HyperMetro.
-func (c *Client) SyncHyperMetroPair(ctx context.Context, hyperMetroPairID string) error {
-	spath := "/HyperMetroPair/synchronize_hcpair"
-	param := struct {
-		ID   string `json:"ID"`
-		TYPE string `json:"TYPE"`
-	}{
-		ID:   hyperMetroPairID,
-		TYPE: strconv.Itoa(TypeHyperMetroPair),
-	}
+func (c *Client) updateHyperMetroPair(ctx context.Context, hyperMetroPairID string, param *UpdateHyperMetroPairParam) error {
+	spath := fmt.Sprintf("/HyperMetroPair/%s", hyperMetroPairID)
+	param.ID = hyperMetroPairID
+	param.TYPE = strconv.Itoa(TypeHyperMetroPair)
+
+	d, _ := c.hyperMetroDevice()
 	jb, err := json.Marshal(param)
 	if err != nil {
 		return fmt.Errorf(ErrCreatePostValue+": %w", err)
 	}
 
-	req, err := c.LocalDevice.newRequest(ctx, "PUT", spath, bytes.NewBuffer(jb))
+	req, err := d.newRequest(ctx, "PUT", spath, bytes.NewBuffer(jb))
 	if err != nil {
 		return fmt.Errorf(ErrCreateRequest+": %w", err)
 	}
 
 	var i interface{} // this endpoint return N/A
-	if err = c.LocalDevice.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
 		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
 	}
 
 	return nil
 }
+
+// State return RUNNINGSTATUS as HyperMetroPairState
+func (hmp *HyperMetroPair) State() HyperMetroPairState {
+	status, err := strconv.Atoi(hmp.RUNNINGSTATUS)
+	if err != nil {
+		return HyperMetroPairStateUnknown
+	}
+
+	return HyperMetroPairState(status)
+}
@@ -2,6 +2,7 @@ package dorado
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -108,3 +109,46 @@ func TestClient_GetHyperMetroPairs(t *testing.T) {
 		t.Errorf("GetHyperMetroPairs return %+v, want %+v", hmps, want)
 	}
 }
+
+func TestClient_ForceStartHyperMetroPair(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	called := false
+	mux.HandleFunc("/HyperMetroPair/e4c2d1eaf02c0001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `{"data": {"ID": "e4c2d1eaf02c0001", "RUNNINGSTATUS": "1", "TYPE": 15361}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/HyperMetroPair/force_start_hcpair", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		fmt.Fprintf(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	err := client.ForceStartHyperMetroPair(context.Background(), "e4c2d1eaf02c0001")
+	if !errors.Is(err, ErrHyperMetroPairUnsafeTransition) {
+		t.Errorf("ForceStartHyperMetroPair return err: %v, want %v", err, ErrHyperMetroPairUnsafeTransition)
+	}
+	if called {
+		t.Errorf("ForceStartHyperMetroPair called force start to normal HyperMetroPair")
+	}
+}
+
+func TestHyperMetroPair_State(t *testing.T) {
+	tests := []struct {
+		input string
+		want  HyperMetroPairState
+	}{
+		{input: "1", want: HyperMetroPairStateNormal},
+		{input: "41", want: HyperMetroPairStatePause},
+		{input: "93", want: HyperMetroPairStateForcedStart},
+		{input: "100", want: HyperMetroPairStateToBeSynchronized},
+		{input: "--", want: HyperMetroPairStateUnknown},
+	}
+
+	for _, test := range tests {
+		hmp := &HyperMetroPair{RUNNINGSTATUS: test.input}
+		if got := hmp.State(); got != test.want {
+			t.Errorf("State(%s) return %s, want %s", test.input, got, test.want)
+		}
+	}
+}
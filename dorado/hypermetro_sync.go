@@ -0,0 +1,168 @@
+package dorado
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Default values of WaitHyperMetroPairSynced
+var (
+	DefaultSyncTimeout  = 30 * time.Minute
+	DefaultSyncInterval = 5 * time.Second
+)
+
+// SyncProgress is progress of HyperMetroPair synchronization
+type SyncProgress struct {
+	HyperMetroPairID string
+	State            HyperMetroPairState
+	Progress         int // percent, -1 is unknown
+	LeftTimeSecond   int // -1 is unknown
+	LinkStatus       string
+}
+
+// WaitSyncOption is optional parameter of WaitHyperMetroPairSynced
+type WaitSyncOption struct {
+	// Timeout is DefaultSyncTimeout if zero.
+	Timeout time.Duration
+	// Interval is DefaultSyncInterval if zero.
+	Interval time.Duration
+	// ProgressFunc is called per polling if set.
+	ProgressFunc func(SyncProgress)
+}
+
+func (o *WaitSyncOption) timeout() time.Duration {
+	if o == nil || o.Timeout == 0 {
+		return DefaultSyncTimeout
+	}
+	return o.Timeout
+}
+
+func (o *WaitSyncOption) interval() time.Duration {
+	if o == nil || o.Interval == 0 {
+		return DefaultSyncInterval
+	}
+	return o.Interval
+}
+
+func (o *WaitSyncOption) report(p SyncProgress) {
+	if o == nil || o.ProgressFunc == nil {
+		return
+	}
+	o.ProgressFunc(p)
+}
+
+// GetSyncProgress return SyncProgress of HyperMetroPair
+func (hmp *HyperMetroPair) GetSyncProgress() SyncProgress {
+	return SyncProgress{
+		HyperMetroPairID: hmp.ID,
+		State:            hmp.State(),
+		Progress:         parseIntOrUnknown(hmp.SYNCPROGRESS),
+		LeftTimeSecond:   parseIntOrUnknown(hmp.SYNCLEFTTIME),
+		LinkStatus:       hmp.LINKSTATUS,
+	}
+}
+
+func parseIntOrUnknown(s string) int {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return -1
+	}
+	return i
+}
+
+// WaitHyperMetroPairSynced wait to become StatusNormal of HyperMetroPair.
+// return error immediately if HyperMetroPair is invalid or not healthy.
+// return ErrHyperMetroPairNotSyncing if HyperMetroPair is not syncing, including to be synchronized (need to call SyncHyperMetroPair).
+func (c *Client) WaitHyperMetroPairSynced(ctx context.Context, hyperMetroPairID string, opts *WaitSyncOption) error {
+	timeout := time.After(opts.timeout())
+	ticker := time.NewTicker(opts.interval())
+	defer ticker.Stop()
+
+	for {
+		hmp, err := c.GetHyperMetroPair(ctx, hyperMetroPairID)
+		if err != nil {
+			return fmt.Errorf("failed to get HyperMetroPair: %w", err)
+		}
+		progress := hmp.GetSyncProgress()
+		opts.report(progress)
+
+		if hmp.HEALTHSTATUS != strconv.Itoa(StatusHealth) {
+			return fmt.Errorf("HyperMetroPair is not healthy (HEALTHSTATUS: %s): %w", hmp.HEALTHSTATUS, ErrHyperMetroPairUnhealthy)
+		}
+		switch progress.State {
+		case HyperMetroPairStateNormal:
+			return nil
+		case HyperMetroPairStateSynchronizing:
+		case HyperMetroPairStateInvalid:
+			return fmt.Errorf("HyperMetroPair is %s: %w", progress.State, ErrHyperMetroPairUnhealthy)
+		default:
+			return fmt.Errorf("HyperMetroPair is %s: %w", progress.State, ErrHyperMetroPairNotSyncing)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			return ErrTimeoutWait
+		case <-ticker.C:
+		}
+	}
+}
+
+// SyncHyperMetroPairWithWait start to sync HyperMetroPair and wait to be synced.
+func (c *Client) SyncHyperMetroPairWithWait(ctx context.Context, hyperMetroPairID string, opts *WaitSyncOption) error {
+	hmp, err := c.GetHyperMetroPair(ctx, hyperMetroPairID)
+	if err != nil {
+		return fmt.Errorf("failed to get HyperMetroPair: %w", err)
+	}
+
+	switch hmp.State() {
+	case HyperMetroPairStateNormal, HyperMetroPairStateSynchronizing:
+	default:
+		if err := c.SyncHyperMetroPair(ctx, hyperMetroPairID); err != nil {
+			return fmt.Errorf("failed to sync HyperMetroPair: %w", err)
+		}
+	}
+
+	if err := c.WaitHyperMetroPairSynced(ctx, hyperMetroPairID, opts); err != nil {
+		return fmt.Errorf("failed to wait HyperMetroPair synced: %w", err)
+	}
+
+	return nil
+}
+
+// SyncHyperMetroConsistencyGroupWithWait start to sync HyperMetro consistency group and wait all pairs in group to be synced.
+// opts is applied to wait each pair.
+func (c *Client) SyncHyperMetroConsistencyGroupWithWait(ctx context.Context, cgID string, opts *WaitSyncOption) error {
+	group, err := c.GetHyperMetroConsistencyGroup(ctx, cgID)
+	if err != nil {
+		return fmt.Errorf("failed to get HyperMetro consistency group: %w", err)
+	}
+
+	status, _ := strconv.Atoi(group.RUNNINGSTATUS)
+	switch HyperMetroPairState(status) {
+	case HyperMetroPairStateNormal, HyperMetroPairStateSynchronizing:
+	default:
+		if err := c.SyncHyperMetroConsistencyGroup(ctx, cgID); err != nil {
+			return fmt.Errorf("failed to sync HyperMetro consistency group: %w", err)
+		}
+	}
+
+	hyperMetroPairs, err := c.GetHyperMetroPairs(ctx, &SearchQuery{Filter: ToFilter("CGID", cgID)})
+	if errors.Is(err, ErrHyperMetroPairNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get HyperMetroPairs in consistency group: %w", err)
+	}
+	for _, hmp := range hyperMetroPairs {
+		if err := c.WaitHyperMetroPairSynced(ctx, hmp.ID, opts); err != nil {
+			return fmt.Errorf("failed to wait HyperMetroPair synced (ID: %s): %w", hmp.ID, err)
+		}
+	}
+
+	return nil
+}
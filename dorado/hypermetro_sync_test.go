@@ -0,0 +1,136 @@
+package dorado
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestClient_WaitHyperMetroPairSynced(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	responses := []string{
+		`{"data": {"ID": "e4c2d1eaf02c0001", "HEALTHSTATUS": "1", "LINKSTATUS": "1", "RUNNINGSTATUS": "23", "SYNCPROGRESS": "50", "SYNCLEFTTIME": "10", "TYPE": 15361}, "error": {"code": 0, "description": "0"}}`,
+		`{"data": {"ID": "e4c2d1eaf02c0001", "HEALTHSTATUS": "1", "LINKSTATUS": "1", "RUNNINGSTATUS": "1", "SYNCPROGRESS": "100", "SYNCLEFTTIME": "-1", "TYPE": 15361}, "error": {"code": 0, "description": "0"}}`,
+	}
+	count := 0
+	mux.HandleFunc("/HyperMetroPair/e4c2d1eaf02c0001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, responses[count])
+		count++
+	})
+
+	var got []SyncProgress
+	err := client.WaitHyperMetroPairSynced(context.Background(), "e4c2d1eaf02c0001", &WaitSyncOption{
+		Interval: 10 * time.Millisecond,
+		ProgressFunc: func(p SyncProgress) {
+			got = append(got, p)
+		},
+	})
+	if err != nil {
+		t.Errorf("WaitHyperMetroPairSynced return err: %s", err)
+	}
+
+	want := []SyncProgress{
+		{HyperMetroPairID: "e4c2d1eaf02c0001", State: HyperMetroPairStateSynchronizing, Progress: 50, LeftTimeSecond: 10, LinkStatus: "1"},
+		{HyperMetroPairID: "e4c2d1eaf02c0001", State: HyperMetroPairStateNormal, Progress: 100, LeftTimeSecond: -1, LinkStatus: "1"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WaitHyperMetroPairSynced report %+v, want %+v", got, want)
+	}
+}
+
+func TestClient_WaitHyperMetroPairSynced_Invalid(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/HyperMetroPair/e4c2d1eaf02c0001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "e4c2d1eaf02c0001", "HEALTHSTATUS": "1", "LINKSTATUS": "2", "RUNNINGSTATUS": "35", "TYPE": 15361}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	err := client.WaitHyperMetroPairSynced(context.Background(), "e4c2d1eaf02c0001", nil)
+	if !errors.Is(err, ErrHyperMetroPairUnhealthy) {
+		t.Errorf("WaitHyperMetroPairSynced return err: %v, want %v", err, ErrHyperMetroPairUnhealthy)
+	}
+}
+
+func TestClient_SyncHyperMetroPairWithWait_ToBeSynchronized(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	synced := false
+	mux.HandleFunc("/HyperMetroPair/synchronize_hcpair", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		synced = true
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	})
+	responses := []string{
+		`{"data": {"ID": "e4c2d1eaf02c0001", "HEALTHSTATUS": "1", "RUNNINGSTATUS": "100", "TYPE": 15361}, "error": {"code": 0, "description": "0"}}`,
+		`{"data": {"ID": "e4c2d1eaf02c0001", "HEALTHSTATUS": "1", "RUNNINGSTATUS": "23", "TYPE": 15361}, "error": {"code": 0, "description": "0"}}`,
+		`{"data": {"ID": "e4c2d1eaf02c0001", "HEALTHSTATUS": "1", "RUNNINGSTATUS": "1", "TYPE": 15361}, "error": {"code": 0, "description": "0"}}`,
+	}
+	count := 0
+	mux.HandleFunc("/HyperMetroPair/e4c2d1eaf02c0001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, responses[count])
+		count++
+	})
+
+	err := client.SyncHyperMetroPairWithWait(context.Background(), "e4c2d1eaf02c0001", &WaitSyncOption{Interval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("SyncHyperMetroPairWithWait return err: %s", err)
+	}
+	if !synced {
+		t.Errorf("SyncHyperMetroPairWithWait must sync to be synchronized pair")
+	}
+}
+
+func TestClient_SyncHyperMetroConsistencyGroupWithWait(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	synced := false
+	mux.HandleFunc("/HyperMetro_ConsistentGroup/a0fc2a6a1e030001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "a0fc2a6a1e030001", "HEALTHSTATUS": "1", "RUNNINGSTATUS": "41", "TYPE": 15364}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/HyperMetro_ConsistentGroup/sync", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		synced = true
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/HyperMetroPair", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got := r.URL.Query().Get("filter"); got != "CGID::a0fc2a6a1e030001" {
+			t.Errorf("filter %s, want CGID::a0fc2a6a1e030001", got)
+		}
+		fmt.Fprint(w, `{"data": [{"ID": "e4c2d1eaf02c0001", "TYPE": 15361}], "error": {"code": 0, "description": "0"}}`)
+	})
+	responses := []string{
+		`{"data": {"ID": "e4c2d1eaf02c0001", "HEALTHSTATUS": "1", "RUNNINGSTATUS": "23", "TYPE": 15361}, "error": {"code": 0, "description": "0"}}`,
+		`{"data": {"ID": "e4c2d1eaf02c0001", "HEALTHSTATUS": "1", "RUNNINGSTATUS": "1", "TYPE": 15361}, "error": {"code": 0, "description": "0"}}`,
+	}
+	count := 0
+	mux.HandleFunc("/HyperMetroPair/e4c2d1eaf02c0001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, responses[count])
+		count++
+	})
+
+	err := client.SyncHyperMetroConsistencyGroupWithWait(context.Background(), "a0fc2a6a1e030001", &WaitSyncOption{Interval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("SyncHyperMetroConsistencyGroupWithWait return err: %s", err)
+	}
+	if !synced {
+		t.Errorf("SyncHyperMetroConsistencyGroupWithWait must sync paused group")
+	}
+	if count != len(responses) {
+		t.Errorf("SyncHyperMetroConsistencyGroupWithWait get HyperMetroPair %d times, want %d", count, len(responses))
+	}
+}
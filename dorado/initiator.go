@@ -24,6 +24,8 @@ type Initiator struct {
 	SPECIALMODETYPE string `json:"SPECIALMODETYPE"`
 	TYPE            int    `json:"TYPE"`
 	USECHAP         string `json:"USECHAP"`
+	CHAPNAME        string `json:"CHAPNAME,omitempty"`
+	NORMALVERMODE   string `json:"NORMALVERMODE,omitempty"`
 	PARENTID        string `json:"PARENTID,omitempty"`
 	PARENTNAME      string `json:"PARENTNAME,omitempty"`
 	PARENTTYPE      int    `json:"PARENTTYPE,omitempty"`
@@ -125,10 +127,37 @@ func (d *Device) DeleteInitiator(ctx context.Context, iqn string) error {
 // UpdateInitiatorParam is parameter for UpdateInitiator
 type UpdateInitiatorParam struct {
 	USECHAP    string `json:"USECHAP"`
-	PARENTTYPE string `json:"PARENTTYPE"`
+	PARENTTYPE string `json:"PARENTTYPE,omitempty"`
 	TYPE       string `json:"TYPE"`
 	ID         string `json:"ID"`
-	PARENTID   string `json:"PARENTID"`
+	PARENTID   string `json:"PARENTID,omitempty"`
+
+	MULTIPATHTYPE   string `json:"MULTIPATHTYPE,omitempty"`
+	FAILOVERMODE    string `json:"FAILOVERMODE,omitempty"`
+	SPECIALMODETYPE string `json:"SPECIALMODETYPE,omitempty"`
+	PATHTYPE        string `json:"PATHTYPE,omitempty"`
+
+	CHAPNAME           string `json:"CHAPNAME,omitempty"`
+	CHAPPASSWORD       string `json:"CHAPPASSWORD,omitempty"`
+	CHAPOLDPASSWORD    string `json:"CHAPOLDPASSWORD,omitempty"`
+	NORMALVERMODE      string `json:"NORMALVERMODE,omitempty"`
+	MUTUALCHAPNAME     string `json:"MUTUALCHAPNAME,omitempty"`
+	MUTUALCHAPPASSWORD string `json:"MUTUALCHAPPASSWORD,omitempty"`
+}
+
+// newUpdateInitiatorParam create UpdateInitiatorParam that keep current values of initiator.
+func newUpdateInitiatorParam(initiator *Initiator) UpdateInitiatorParam {
+	param := UpdateInitiatorParam{
+		ID:       initiator.ID,
+		TYPE:     strconv.Itoa(TypeInitiator),
+		USECHAP:  initiator.USECHAP,
+		PARENTID: initiator.PARENTID,
+	}
+	if initiator.PARENTID != "" {
+		param.PARENTTYPE = strconv.Itoa(initiator.PARENTTYPE)
+	}
+
+	return param
 }
 
 // UpdateInitiator update initiator information.
@@ -170,3 +199,175 @@ func (d *Device) GetInitiatorForce(ctx context.Context, iqn string) (*Initiator,
 
 	return &initiators[0], nil
 }
+
+// AccessMode is multipath and ALUA parameter of initiator.
+// FailoverMode, SpecialModeType and PathType are used only MultipathType is MultipathTypeThirdParty.
+type AccessMode struct {
+	MultipathType   int
+	FailoverMode    int
+	SpecialModeType int
+	PathType        int
+}
+
+// Validate check value of AccessMode
+func (am *AccessMode) Validate() error {
+	if am.MultipathType != MultipathTypeDefault && am.MultipathType != MultipathTypeThirdParty {
+		return fmt.Errorf("invalid multipath type: %d", am.MultipathType)
+	}
+	if am.FailoverMode < FailoverModeOldALUA || am.FailoverMode > FailoverModeSpecialALUA {
+		return fmt.Errorf("invalid failover mode: %d", am.FailoverMode)
+	}
+	if am.SpecialModeType < SpecialModeType0 || am.SpecialModeType > SpecialModeType3 {
+		return fmt.Errorf("invalid special mode type: %d", am.SpecialModeType)
+	}
+	if am.PathType != PathTypeOptimized && am.PathType != PathTypeNonOptimized {
+		return fmt.Errorf("invalid path type: %d", am.PathType)
+	}
+
+	return nil
+}
+
+// toParam set access mode values to UpdateInitiatorParam
+func (am *AccessMode) toParam(param *UpdateInitiatorParam) {
+	param.MULTIPATHTYPE = strconv.Itoa(am.MultipathType)
+	if am.MultipathType != MultipathTypeThirdParty {
+		return
+	}
+
+	param.FAILOVERMODE = strconv.Itoa(am.FailoverMode)
+	param.PATHTYPE = strconv.Itoa(am.PathType)
+	if am.FailoverMode == FailoverModeSpecialALUA {
+		param.SPECIALMODETYPE = strconv.Itoa(am.SpecialModeType)
+	}
+}
+
+// SetInitiatorAccessMode set multipath and ALUA parameter of initiator.
+func (d *Device) SetInitiatorAccessMode(ctx context.Context, iqn string, mode AccessMode) (*Initiator, error) {
+	if err := mode.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate access mode: %w", err)
+	}
+
+	initiator, err := d.GetInitiator(ctx, iqn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get initiator: %w", err)
+	}
+
+	param := newUpdateInitiatorParam(initiator)
+	mode.toParam(&param)
+
+	return d.UpdateInitiator(ctx, iqn, param)
+}
+
+// CHAPCredential is credential of iSCSI CHAP authentication.
+// set MutualName and MutualSecret if use mutual CHAP (target authenticate to initiator).
+type CHAPCredential struct {
+	Name         string
+	Secret       string
+	MutualName   string
+	MutualSecret string
+}
+
+// IsMutual return true if credential has mutual CHAP value
+func (cc *CHAPCredential) IsMutual() bool {
+	return cc.MutualName != "" || cc.MutualSecret != ""
+}
+
+// Validate check credential is acceptable for Dorado.
+func (cc *CHAPCredential) Validate() error {
+	if cc.Name == "" {
+		return errors.New("CHAP name is required")
+	}
+	if err := validateCHAPSecret(cc.Secret); err != nil {
+		return fmt.Errorf("invalid CHAP secret: %w", err)
+	}
+
+	if cc.IsMutual() {
+		if cc.MutualName == "" {
+			return errors.New("mutual CHAP name is required")
+		}
+		if err := validateCHAPSecret(cc.MutualSecret); err != nil {
+			return fmt.Errorf("invalid mutual CHAP secret: %w", err)
+		}
+		if cc.MutualSecret == cc.Secret {
+			// RFC 3720 say that secret must be different for each direction
+			return errors.New("mutual CHAP secret must be different from CHAP secret")
+		}
+	}
+
+	return nil
+}
+
+func validateCHAPSecret(secret string) error {
+	if len(secret) < MinCHAPSecretLength || len(secret) > MaxCHAPSecretLength {
+		return fmt.Errorf("length of secret must be between %d and %d", MinCHAPSecretLength, MaxCHAPSecretLength)
+	}
+
+	return nil
+}
+
+// toParam set CHAP values to UpdateInitiatorParam
+func (cc *CHAPCredential) toParam(param *UpdateInitiatorParam) {
+	param.USECHAP = "true"
+	param.CHAPNAME = cc.Name
+	param.CHAPPASSWORD = cc.Secret
+	param.NORMALVERMODE = strconv.Itoa(CHAPModeOneWay)
+
+	if cc.IsMutual() {
+		param.NORMALVERMODE = strconv.Itoa(CHAPModeMutual)
+		param.MUTUALCHAPNAME = cc.MutualName
+		param.MUTUALCHAPPASSWORD = cc.MutualSecret
+	}
+}
+
+// SetInitiatorCHAP enable CHAP authentication of initiator.
+// enable mutual CHAP if credential has mutual values.
+func (d *Device) SetInitiatorCHAP(ctx context.Context, iqn string, credential CHAPCredential) (*Initiator, error) {
+	if err := credential.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate CHAP credential: %w", err)
+	}
+
+	initiator, err := d.GetInitiator(ctx, iqn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get initiator: %w", err)
+	}
+
+	param := newUpdateInitiatorParam(initiator)
+	credential.toParam(&param)
+
+	return d.UpdateInitiator(ctx, iqn, param)
+}
+
+// RotateInitiatorCHAP change CHAP secret of initiator that already enabled CHAP.
+// Dorado require current secret when to change secret.
+func (d *Device) RotateInitiatorCHAP(ctx context.Context, iqn, oldSecret string, credential CHAPCredential) (*Initiator, error) {
+	if err := credential.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate CHAP credential: %w", err)
+	}
+
+	initiator, err := d.GetInitiator(ctx, iqn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get initiator: %w", err)
+	}
+	if initiator.USECHAP != "true" {
+		return nil, fmt.Errorf("CHAP is not enabled in initiator (IQN: %s)", iqn)
+	}
+
+	param := newUpdateInitiatorParam(initiator)
+	param.CHAPOLDPASSWORD = oldSecret
+	credential.toParam(&param)
+
+	return d.UpdateInitiator(ctx, iqn, param)
+}
+
+// ClearInitiatorCHAP disable CHAP authentication of initiator.
+func (d *Device) ClearInitiatorCHAP(ctx context.Context, iqn string) (*Initiator, error) {
+	initiator, err := d.GetInitiator(ctx, iqn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get initiator: %w", err)
+	}
+
+	param := newUpdateInitiatorParam(initiator)
+	param.USECHAP = "false"
+
+	return d.UpdateInitiator(ctx, iqn, param)
+}
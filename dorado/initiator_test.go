@@ -2,6 +2,7 @@ package dorado
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -70,3 +71,145 @@ func TestDevice_GetInitiators(t *testing.T) {
 		t.Errorf("GetInitiators return %+v, want %+v", initiators, want)
 	}
 }
+
+func TestCHAPCredential_Validate(t *testing.T) {
+	tests := []struct {
+		input   CHAPCredential
+		wantErr bool
+	}{
+		{
+			input:   CHAPCredential{Name: "user", Secret: "secret123456"},
+			wantErr: false,
+		},
+		{
+			input:   CHAPCredential{Name: "", Secret: "secret123456"},
+			wantErr: true,
+		},
+		{
+			input:   CHAPCredential{Name: "user", Secret: "short"},
+			wantErr: true,
+		},
+		{
+			input:   CHAPCredential{Name: "user", Secret: "secret123456", MutualName: "target", MutualSecret: "mutual123456"},
+			wantErr: false,
+		},
+		{
+			input:   CHAPCredential{Name: "user", Secret: "secret123456", MutualName: "target", MutualSecret: "secret123456"},
+			wantErr: true,
+		},
+		{
+			input:   CHAPCredential{Name: "user", Secret: "secret123456", MutualSecret: "mutual123456"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		err := test.input.Validate()
+		if (err != nil) != test.wantErr {
+			t.Errorf("Validate(%+v) return err: %v, wantErr %v", test.input, err, test.wantErr)
+		}
+	}
+}
+
+func TestDevice_SetInitiatorCHAP(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/iscsi_initiator/iqn.1993-08.org.debian:01:test", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"data": {"ID": "iqn.1993-08.org.debian:01:test", "USECHAP": "false", "PARENTID": "1", "PARENTTYPE": 21, "TYPE": 222}, "error": {"code": 0, "description": "0"}}`)
+		case "PUT":
+			var got UpdateInitiatorParam
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode request body: %s", err)
+			}
+			want := UpdateInitiatorParam{
+				USECHAP:            "true",
+				PARENTTYPE:         "21",
+				TYPE:               "222",
+				ID:                 "iqn.1993-08.org.debian:01:test",
+				PARENTID:           "1",
+				CHAPNAME:           "user",
+				CHAPPASSWORD:       "secret123456",
+				NORMALVERMODE:      "1",
+				MUTUALCHAPNAME:     "target",
+				MUTUALCHAPPASSWORD: "mutual123456",
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("UpdateInitiator request %+v, want %+v", got, want)
+			}
+			fmt.Fprint(w, `{"data": {"ID": "iqn.1993-08.org.debian:01:test", "USECHAP": "true", "CHAPNAME": "user", "NORMALVERMODE": "1", "PARENTID": "1", "PARENTTYPE": 21, "TYPE": 222}, "error": {"code": 0, "description": "0"}}`)
+		default:
+			t.Errorf("Request method: %v, want GET or PUT", r.Method)
+		}
+	})
+
+	credential := CHAPCredential{
+		Name:         "user",
+		Secret:       "secret123456",
+		MutualName:   "target",
+		MutualSecret: "mutual123456",
+	}
+	initiator, err := client.LocalDevice.SetInitiatorCHAP(context.Background(), "iqn.1993-08.org.debian:01:test", credential)
+	if err != nil {
+		t.Fatalf("SetInitiatorCHAP return err: %s", err)
+	}
+
+	if initiator.USECHAP != "true" || initiator.CHAPNAME != "user" {
+		t.Errorf("SetInitiatorCHAP return %+v, want CHAP enabled initiator", initiator)
+	}
+}
+
+func TestDevice_setupInitiator_KeepCHAP(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/iscsi_initiator", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": [{"ID": "iqn.1993-08.org.debian:01:test", "USECHAP": "true", "CHAPNAME": "user", "ISFREE": "true", "TYPE": 222}], "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/iscsi_initiator/iqn.1993-08.org.debian:01:test", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		var got map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+		if got["USECHAP"] != "true" || got["PARENTID"] != "1" {
+			t.Errorf("UpdateInitiator request %+v, want USECHAP true and PARENTID 1", got)
+		}
+		fmt.Fprint(w, `{"data": {"ID": "iqn.1993-08.org.debian:01:test", "USECHAP": "true", "PARENTID": "1", "PARENTTYPE": 21, "TYPE": 222}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	if err := client.LocalDevice.setupInitiator(context.Background(), "iqn.1993-08.org.debian:01:test", 1, &AttachVolumeOption{}); err != nil {
+		t.Fatalf("setupInitiator return err: %s", err)
+	}
+}
+
+func TestAccessMode_toParam(t *testing.T) {
+	tests := []struct {
+		input AccessMode
+		want  UpdateInitiatorParam
+	}{
+		{
+			input: AccessMode{MultipathType: MultipathTypeDefault, PathType: PathTypeNonOptimized},
+			want:  UpdateInitiatorParam{MULTIPATHTYPE: "0"},
+		},
+		{
+			input: AccessMode{MultipathType: MultipathTypeThirdParty, FailoverMode: FailoverModeCommonALUA, PathType: PathTypeNonOptimized},
+			want:  UpdateInitiatorParam{MULTIPATHTYPE: "1", FAILOVERMODE: "1", PATHTYPE: "1"},
+		},
+		{
+			input: AccessMode{MultipathType: MultipathTypeThirdParty, FailoverMode: FailoverModeSpecialALUA, SpecialModeType: SpecialModeType2, PathType: PathTypeOptimized},
+			want:  UpdateInitiatorParam{MULTIPATHTYPE: "1", FAILOVERMODE: "3", SPECIALMODETYPE: "2", PATHTYPE: "0"},
+		},
+	}
+
+	for _, test := range tests {
+		got := UpdateInitiatorParam{}
+		test.input.toParam(&got)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("toParam(%+v) set %+v, want %+v", test.input, got, test.want)
+		}
+	}
+}
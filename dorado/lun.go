@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -99,6 +100,10 @@ type ParamCreateLUN struct {
 	NAME               string `json:"NAME"`
 	WORKLOADTYPEID     string `json:"WORKLOADTYPEID"`
 	PREFETCHPOLICY     string `json:"PREFETCHPOLICY"`
+	ENABLESMARTDEDUP   *bool  `json:"ENABLESMARTDEDUP,omitempty"`
+	ENABLECOMPRESSION  *bool  `json:"ENABLECOMPRESSION,omitempty"`
+	OWNINGCONTROLLER   string `json:"OWNINGCONTROLLER,omitempty"`
+	SECTORSIZE         string `json:"SECTORSIZE,omitempty"`
 }
 
 // ParamCreateCloneLUN is parameter for CreateCloneLUN
@@ -108,6 +113,111 @@ type ParamCreateCloneLUN struct {
 	ISCLONE       bool   `json:"ISCLONE"`
 }
 
+// For LUN ALLOCTYPE
+const (
+	AllocTypeThick = 0
+	AllocTypeThin  = 1
+)
+
+// MaxLUNDescriptionLength is max length of LUN DESCRIPTION
+const MaxLUNDescriptionLength = 255
+
+var owningControllerRegexp = regexp.MustCompile(`^[0-9]+[A-Z]$`)
+
+// LUNSpec is optional properties of new LUN.
+// zero value is same as default of CreateLUN.
+type LUNSpec struct {
+	// Thick create thick LUN if true, default is thin LUN.
+	Thick bool
+	// EnableSmartDedup and EnableCompression use default of device if nil.
+	// thick LUN can't enable them.
+	EnableSmartDedup  *bool
+	EnableCompression *bool
+	// WorkloadTypeID is ID of workload type in device, "0" if empty.
+	WorkloadTypeID string
+	// OwningController is controller name (e.g. "0A"). device choose if empty.
+	OwningController string
+	// SectorSize is 512 or 4096 bytes. device default if zero.
+	SectorSize int
+	// Description is PrefixVolumeDescription + name if empty.
+	Description string
+}
+
+// Validate validate parameter
+func (s *LUNSpec) Validate() error {
+	if s == nil {
+		return nil
+	}
+
+	if s.Thick && ((s.EnableSmartDedup != nil && *s.EnableSmartDedup) || (s.EnableCompression != nil && *s.EnableCompression)) {
+		return fmt.Errorf("dedup and compression can't be enabled in thick LUN")
+	}
+	if s.WorkloadTypeID != "" {
+		if _, err := strconv.Atoi(s.WorkloadTypeID); err != nil {
+			return fmt.Errorf("invalid workload type ID: %s", s.WorkloadTypeID)
+		}
+	}
+	if s.OwningController != "" && !owningControllerRegexp.MatchString(s.OwningController) {
+		return fmt.Errorf("invalid owning controller: %s", s.OwningController)
+	}
+	switch s.SectorSize {
+	case 0, 512, 4096:
+	default:
+		return fmt.Errorf("invalid sector size: %d", s.SectorSize)
+	}
+	if len(s.Description) > MaxLUNDescriptionLength {
+		return fmt.Errorf("description must be less than %d characters", MaxLUNDescriptionLength)
+	}
+
+	return nil
+}
+
+// apply set properties to parameter of CreateLUN
+func (s *LUNSpec) apply(p *ParamCreateLUN) {
+	if s == nil {
+		return
+	}
+
+	if s.Thick {
+		p.ALLOCTYPE = AllocTypeThick
+	}
+	p.ENABLESMARTDEDUP = s.EnableSmartDedup
+	p.ENABLECOMPRESSION = s.EnableCompression
+	if s.WorkloadTypeID != "" {
+		p.WORKLOADTYPEID = s.WorkloadTypeID
+	}
+	p.OWNINGCONTROLLER = s.OwningController
+	if s.SectorSize != 0 {
+		p.SECTORSIZE = strconv.Itoa(s.SectorSize)
+	}
+	if s.Description != "" {
+		p.DESCRIPTION = s.Description
+	}
+}
+
+// lunSpecFromLUN return LUNSpec that has same properties of lun
+func lunSpecFromLUN(lun *LUN) *LUNSpec {
+	spec := &LUNSpec{
+		Thick:            lun.ALLOCTYPE == strconv.Itoa(AllocTypeThick),
+		WorkloadTypeID:   lun.WORKLOADTYPEID,
+		OwningController: lun.OWNINGCONTROLLER,
+		Description:      lun.DESCRIPTION,
+	}
+	if !spec.Thick {
+		if b, err := strconv.ParseBool(lun.ENABLESMARTDEDUP); err == nil {
+			spec.EnableSmartDedup = &b
+		}
+		if b, err := strconv.ParseBool(lun.ENABLECOMPRESSION); err == nil {
+			spec.EnableCompression = &b
+		}
+	}
+	if size, err := strconv.Atoi(lun.SECTORSIZE); err == nil {
+		spec.SectorSize = size
+	}
+
+	return spec
+}
+
 // PrefixVolumeDescription is prefix of volume Description
 var PrefixVolumeDescription = "volume-"
 
@@ -163,33 +273,57 @@ func (d *Device) GetLUN(ctx context.Context, lunID int) (*LUN, error) {
 	return lun, nil
 }
 
-// CreateLUN create lun object
-func (d *Device) CreateLUN(ctx context.Context, u uuid.UUID, capacityGB int, storagePoolName string) (*LUN, error) {
+// getStoragePoolID get ID of storage pool by name
+func (d *Device) getStoragePoolID(ctx context.Context, storagePoolName string) (int, error) {
 	storagePools, err := d.GetStoragePools(ctx, NewSearchQueryName(storagePoolName))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get storagepool: %w", err)
+		return 0, fmt.Errorf("failed to get storagepool: %w", err)
 	}
 
 	if len(storagePools) != 1 {
-		return nil, errors.New("found multiple storagepool in same name")
+		return 0, errors.New("found multiple storagepool in same name")
 	}
-	storagePoolID := storagePools[0].ID
 
-	p := ParamCreateLUN{
-		NAME:               EncodeLunName(u),
+	return storagePools[0].ID, nil
+}
+
+// CreateLUN create lun object
+func (d *Device) CreateLUN(ctx context.Context, u uuid.UUID, capacityGB int, storagePoolName string) (*LUN, error) {
+	return d.CreateLUNWithSpec(ctx, u, capacityGB, storagePoolName, nil)
+}
+
+// CreateLUNWithSpec create lun object with spec. spec is optional, use default properties if nil.
+func (d *Device) CreateLUNWithSpec(ctx context.Context, u uuid.UUID, capacityGB int, storagePoolName string, spec *LUNSpec) (*LUN, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate LUN spec: %w", err)
+	}
+
+	storagePoolID, err := d.getStoragePoolID(ctx, storagePoolName)
+	if err != nil {
+		return nil, err
+	}
+
+	p := newParamCreateLUN(EncodeLunName(u), storagePoolID, PrefixVolumeDescription+u.String(), capacityGB*CapacityUnit)
+	spec.apply(&p)
+
+	return d.createLUN(ctx, p)
+}
+
+// newParamCreateLUN return parameter of CreateLUN that has default properties
+func newParamCreateLUN(name string, storagePoolID int, description string, capacity int) ParamCreateLUN {
+	return ParamCreateLUN{
+		NAME:               name,
 		PARENTID:           strconv.Itoa(storagePoolID),
-		DESCRIPTION:        PrefixVolumeDescription + u.String(),
-		CAPACITY:           capacityGB * CapacityUnit,
+		DESCRIPTION:        description,
+		CAPACITY:           capacity,
 		WRITEPOLICY:        "1",
 		PREFETCHVALUE:      "0",
-		ALLOCTYPE:          1,
+		ALLOCTYPE:          AllocTypeThin,
 		MIRRORPOLICY:       "1",
 		DATATRANSFERPOLICY: "0",
 		WORKLOADTYPEID:     "0",
 		PREFETCHPOLICY:     "3",
 	}
-
-	return d.createLUN(ctx, p)
 }
 
 func (d *Device) createLUN(ctx context.Context, param interface{}) (*LUN, error) {
@@ -214,7 +348,12 @@ func (d *Device) createLUN(ctx context.Context, param interface{}) (*LUN, error)
 
 // CreateLUNWithWait create LUN and waiting ready
 func (d *Device) CreateLUNWithWait(ctx context.Context, u uuid.UUID, capacityGB int, storagePoolName string) (*LUN, error) {
-	lun, err := d.CreateLUN(ctx, u, capacityGB, storagePoolName)
+	return d.CreateLUNWithSpecWithWait(ctx, u, capacityGB, storagePoolName, nil)
+}
+
+// CreateLUNWithSpecWithWait create LUN with spec and waiting ready
+func (d *Device) CreateLUNWithSpecWithWait(ctx context.Context, u uuid.UUID, capacityGB int, storagePoolName string, spec *LUNSpec) (*LUN, error) {
+	lun, err := d.CreateLUNWithSpec(ctx, u, capacityGB, storagePoolName, spec)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create LUN: %w", err)
 	}
@@ -252,7 +391,16 @@ func (d *Device) lunIsReady(ctx context.Context, LUNID int) (bool, error) {
 }
 
 // DeleteLUN delete lun object (also include data)
+// return ErrLunHasClones if LUN has clone LUNs that are not split.
 func (d *Device) DeleteLUN(ctx context.Context, lunID int) error {
+	lun, err := d.GetLUN(ctx, lunID)
+	if err != nil {
+		return fmt.Errorf("failed to get LUN: %w", err)
+	}
+	if err := lun.checkNoClones(); err != nil {
+		return err
+	}
+
 	spath := fmt.Sprintf("/lun/%d", lunID)
 	req, err := d.newRequest(ctx, "DELETE", spath, nil)
 	if err != nil {
@@ -329,27 +477,57 @@ func (d *Device) GetHostAssociatedLUNs(ctx context.Context, hostID int) ([]LUN,
 	return d.GetAssociateLUNs(ctx, query)
 }
 
+// GetLunGroupAssociatedLUNs get LUNs associated specific lun group
+func (d *Device) GetLunGroupAssociatedLUNs(ctx context.Context, lungroupID int) ([]LUN, error) {
+	query := &SearchQuery{
+		AssociateObjType: strconv.Itoa(TypeLUNGroup),
+		AssociateObjID:   strconv.Itoa(lungroupID),
+	}
+
+	return d.GetAssociateLUNs(ctx, query)
+}
+
 // GetHostLUNID get LUN ID per host.
 func (d *Device) GetHostLUNID(ctx context.Context, lunID, hostID int) (int, error) {
+	hostLUNIDs, err := d.GetHostLUNIDs(ctx, hostID)
+	if err != nil {
+		return 0, err
+	}
+
+	hostLUNID, ok := hostLUNIDs[lunID]
+	if !ok {
+		return 0, fmt.Errorf("LUN (ID: %d) is not associated host (ID: %d)", lunID, hostID)
+	}
+
+	return hostLUNID, nil
+}
+
+// GetHostLUNIDs get LUN ID per host of all LUNs that associated host.
+// return map of LUN ID to host LUN ID.
+func (d *Device) GetHostLUNIDs(ctx context.Context, hostID int) (map[int]int, error) {
+	hostLUNIDs := map[int]int{}
+
 	luns, err := d.GetHostAssociatedLUNs(ctx, hostID)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get associated LUNs: %w", err)
+		if err == ErrLunNotFound {
+			return hostLUNIDs, nil
+		}
+
+		return nil, fmt.Errorf("failed to get associated LUNs: %w", err)
 	}
 
 	for _, lun := range luns {
-		if lun.ID == lunID {
-			jsonStr := lun.ASSOCIATEMETADATA
-			hostLunID := AssociateMetaData{}
-			err := json.Unmarshal([]byte(jsonStr), &hostLunID)
-			if err != nil {
-				return 0, fmt.Errorf("failed to parse ASSOCIATEMETADATA: %w", err)
-			}
-
-			return hostLunID.HostLUNID, nil
+		jsonStr := lun.ASSOCIATEMETADATA
+		hostLunID := AssociateMetaData{}
+		err := json.Unmarshal([]byte(jsonStr), &hostLunID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ASSOCIATEMETADATA: %w", err)
 		}
+
+		hostLUNIDs[lun.ID] = hostLunID.HostLUNID
 	}
 
-	return 0, fmt.Errorf("LUN (ID: %d) is not associated host (ID: %d)", lunID, hostID)
+	return hostLUNIDs, nil
 }
 
 // CreateCloneLUN create clone LUN
@@ -370,32 +548,5 @@ func (d *Device) CreateCloneLUN(ctx context.Context, lunID int, lunName uuid.UUI
 
 // SplitCloneLUN start to split LUN Clone
 func (d *Device) SplitCloneLUN(ctx context.Context, cloneLUNID int) error {
-	spath := "/lunclone_split_switch"
-	param := struct {
-		ID          int  `json:"ID"`
-		SPLITACTION int  `json:"SPLITACTION"`
-		ISCLONE     bool `json:"ISCLONE"`
-		SPLITSPEED  int  `json:"SPLITSPEED"`
-	}{
-		ID:          cloneLUNID,
-		SPLITACTION: 1,
-		ISCLONE:     true,
-		SPLITSPEED:  4,
-	}
-	jb, err := json.Marshal(param)
-	if err != nil {
-		return fmt.Errorf(ErrCreatePostValue+": %w", err)
-	}
-
-	req, err := d.newRequest(ctx, "PUT", spath, bytes.NewBuffer(jb))
-	if err != nil {
-		return fmt.Errorf(ErrCreateRequest+": %w", err)
-	}
-
-	var i interface{} // this endpoint return N/A
-	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
-		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
-	}
-
-	return nil
+	return d.SplitCloneLUNWithSpeed(ctx, cloneLUNID, SpeedHighest)
 }
@@ -0,0 +1,175 @@
+package dorado
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// CloneIDs return IDs of clone LUNs that are not split from LUN.
+func (l *LUN) CloneIDs() ([]int, error) {
+	cloneIDs, err := parseIDList(l.CLONEIDS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CLONEIDS: %w", err)
+	}
+
+	return cloneIDs, nil
+}
+
+// parseIDList parse list of ID (e.g. "[\"1\",\"2\"]")
+func parseIDList(s string) ([]int, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var values []string
+	if err := json.Unmarshal([]byte(s), &values); err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for _, value := range values {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// checkNoClones return ErrLunHasClones if LUN has dependent clone LUNs.
+func (l *LUN) checkNoClones() error {
+	cloneIDs, err := l.CloneIDs()
+	if err != nil {
+		return err
+	}
+	if len(cloneIDs) != 0 {
+		return fmt.Errorf("LUN (ID: %d) has clone LUNs %v: %w", l.ID, cloneIDs, ErrLunHasClones)
+	}
+
+	return nil
+}
+
+// GetCloneLUNs get clone LUNs that are not split from source LUN.
+func (d *Device) GetCloneLUNs(ctx context.Context, sourceLUNID int) ([]LUN, error) {
+	source, err := d.GetLUN(ctx, sourceLUNID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source LUN: %w", err)
+	}
+
+	cloneIDs, err := source.CloneIDs()
+	if err != nil {
+		return nil, err
+	}
+	if len(cloneIDs) == 0 {
+		return nil, ErrLunNotFound
+	}
+
+	var clones []LUN
+	for _, cloneID := range cloneIDs {
+		clone, err := d.GetLUN(ctx, cloneID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get clone LUN (ID: %d): %w", cloneID, err)
+		}
+		clones = append(clones, *clone)
+	}
+
+	return clones, nil
+}
+
+// CreateLinkedCloneLUN create clone LUN that is not split from source LUN.
+// clone LUN is created immediately but depend on source LUN until split.
+func (d *Device) CreateLinkedCloneLUN(ctx context.Context, sourceLUNID int, name uuid.UUID, capacityGB int) (*LUN, error) {
+	cloneLUN, err := d.CreateCloneLUN(ctx, sourceLUNID, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create clone LUN: %w", err)
+	}
+
+	if cloneLUN.CAPACITY < capacityGB*CapacityUnit {
+		if err := d.ExpandLUN(ctx, cloneLUN.ID, capacityGB); err != nil {
+			if err := d.DeleteLUN(ctx, cloneLUN.ID); err != nil {
+				d.Logger.Printf("failed to delete LUN: %v", err)
+			}
+			return nil, fmt.Errorf("failed to expand LUN: %w", err)
+		}
+		return d.GetLUN(ctx, cloneLUN.ID)
+	}
+
+	return cloneLUN, nil
+}
+
+// SplitCloneLUNWithSpeed start to split LUN Clone by speed (SpeedLow to SpeedHighest).
+// also resume paused split.
+func (d *Device) SplitCloneLUNWithSpeed(ctx context.Context, cloneLUNID, speed int) error {
+	if speed < SpeedLow || speed > SpeedHighest {
+		return fmt.Errorf("invalid speed: %d", speed)
+	}
+
+	return d.switchSplitCloneLUN(ctx, cloneLUNID, SplitActionStart, speed)
+}
+
+// PauseSplitCloneLUN pause to split LUN Clone. resume by SplitCloneLUNWithSpeed.
+func (d *Device) PauseSplitCloneLUN(ctx context.Context, cloneLUNID int) error {
+	return d.switchSplitCloneLUN(ctx, cloneLUNID, SplitActionPause, 0)
+}
+
+// StopSplitCloneLUN stop to split LUN Clone. clone LUN is still depend on source LUN.
+func (d *Device) StopSplitCloneLUN(ctx context.Context, cloneLUNID int) error {
+	return d.switchSplitCloneLUN(ctx, cloneLUNID, SplitActionStop, 0)
+}
+
+func (d *Device) switchSplitCloneLUN(ctx context.Context, cloneLUNID, action, speed int) error {
+	spath := "/lunclone_split_switch"
+	param := struct {
+		ID          int  `json:"ID"`
+		SPLITACTION int  `json:"SPLITACTION"`
+		ISCLONE     bool `json:"ISCLONE"`
+		SPLITSPEED  int  `json:"SPLITSPEED,omitempty"`
+	}{
+		ID:          cloneLUNID,
+		SPLITACTION: action,
+		ISCLONE:     true,
+		SPLITSPEED:  speed,
+	}
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+
+	req, err := d.newRequest(ctx, "PUT", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// WaitSplitCloneLUN wait to finish split of LUN Clone.
+func (d *Device) WaitSplitCloneLUN(ctx context.Context, cloneLUNID int) (*LUN, error) {
+	for i := 0; i < DefaultCopyTimeoutSecond; i++ {
+		isReady, err := d.lunIsReady(ctx, cloneLUNID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to wait that LUN is ready: %w", err)
+		}
+
+		if isReady == true {
+			return d.GetLUN(ctx, cloneLUNID)
+		}
+
+		time.Sleep(1 * time.Second)
+	}
+
+	return nil, ErrTimeoutWait
+}
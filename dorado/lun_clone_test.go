@@ -0,0 +1,132 @@
+package dorado
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestLUN_CloneIDs(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []int
+	}{
+		{input: "", want: nil},
+		{input: "[]", want: nil},
+		{input: `["7","8"]`, want: []int{7, 8}},
+	}
+
+	for _, test := range tests {
+		lun := &LUN{CLONEIDS: test.input}
+		got, err := lun.CloneIDs()
+		if err != nil {
+			t.Fatalf("CloneIDs(%q) return err: %s", test.input, err)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("CloneIDs(%q) return %v, want %v", test.input, got, test.want)
+		}
+	}
+}
+
+func TestDevice_GetCloneLUNs(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/lun/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "2", "CLONEIDS": "[\"6\"]", "ISCLONE": "false", "TYPE": 11}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/lun/6", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "6", "CLONEIDS": "[]", "ISCLONE": "true", "TYPE": 11}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	clones, err := client.LocalDevice.GetCloneLUNs(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("GetCloneLUNs return err: %s", err)
+	}
+	if len(clones) != 1 || clones[0].ID != 6 || !clones[0].ISCLONE {
+		t.Errorf("GetCloneLUNs return %+v, want clone LUN 6", clones)
+	}
+}
+
+func TestDevice_SplitCloneLUNWithSpeed(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var got []map[string]interface{}
+	mux.HandleFunc("/lunclone_split_switch", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		var param map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
+			t.Fatalf("failed to decode request: %s", err)
+		}
+		got = append(got, param)
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	if err := client.LocalDevice.SplitCloneLUNWithSpeed(context.Background(), 6, SpeedLow); err != nil {
+		t.Fatalf("SplitCloneLUNWithSpeed return err: %s", err)
+	}
+	if err := client.LocalDevice.PauseSplitCloneLUN(context.Background(), 6); err != nil {
+		t.Fatalf("PauseSplitCloneLUN return err: %s", err)
+	}
+	if err := client.LocalDevice.SplitCloneLUNWithSpeed(context.Background(), 6, 5); err == nil {
+		t.Errorf("SplitCloneLUNWithSpeed must return err if speed is invalid")
+	}
+
+	want := []map[string]interface{}{
+		{"ID": float64(6), "SPLITACTION": float64(SplitActionStart), "ISCLONE": true, "SPLITSPEED": float64(SpeedLow)},
+		{"ID": float64(6), "SPLITACTION": float64(SplitActionPause), "ISCLONE": true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("request body %+v, want %+v", got, want)
+	}
+}
+
+func TestDevice_DeleteLUN_HasClones(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/lun/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "2", "CLONEIDS": "[\"6\"]", "ISCLONE": "false", "TYPE": 11}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	err := client.LocalDevice.DeleteLUN(context.Background(), 2)
+	if !errors.Is(err, ErrLunHasClones) {
+		t.Errorf("DeleteLUN return err: %v, want %v", err, ErrLunHasClones)
+	}
+}
+
+func TestClient_DeleteVolume_RemoteHasClones(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	remoteMux, remoteTeardown := setupRemote(client)
+	defer remoteTeardown()
+
+	mux.HandleFunc("/HyperMetroPair/e4c2d1eaf02c0001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "e4c2d1eaf02c0001", "LOCALOBJID": "2", "REMOTEOBJID": "3", "RUNNINGSTATUS": "1", "TYPE": 15361}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/lun/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "2", "CLONEIDS": "[]", "ISADD2LUNGROUP": "true", "TYPE": 11}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/lungroup/associate", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("DeleteVolume must not disassociate LUN if remote LUN has clones")
+	})
+	remoteMux.HandleFunc("/lun/3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "3", "CLONEIDS": "[\"6\"]", "TYPE": 11}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	err := client.DeleteVolume(context.Background(), "e4c2d1eaf02c0001")
+	if !errors.Is(err, ErrLunHasClones) {
+		t.Errorf("DeleteVolume return err: %v, want %v", err, ErrLunHasClones)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -71,19 +72,128 @@ func (d *Device) GetLUNCopy(ctx context.Context, lunCopyID int) (*LunCopy, error
 	return lunCopys, nil
 }
 
+// LUNCopyTarget is target LUN of LUN copy
+type LUNCopyTarget struct {
+	// RemoteArrayID is ID of remote device that has target LUN.
+	// target LUN is in same device if empty.
+	RemoteArrayID string
+	LUNID         int
+	// LUNWWN is WWN of target LUN, required if RemoteArrayID is set.
+	LUNWWN string
+}
+
+// LocalLUNCopyTarget return LUNCopyTarget of LUN in same device
+func LocalLUNCopyTarget(lunID int) LUNCopyTarget {
+	return LUNCopyTarget{LUNID: lunID}
+}
+
+// String return value of TARGETLUN
+func (t LUNCopyTarget) String() string {
+	array, wwn := "INVALID", "INVALID"
+	if t.RemoteArrayID != "" {
+		array = t.RemoteArrayID
+	}
+	if t.LUNWWN != "" {
+		wwn = t.LUNWWN
+	}
+
+	return fmt.Sprintf("%s;%d;%s;INVALID;INVALID", array, t.LUNID, wwn)
+}
+
+// RemoteLUNCopyTarget return LUNCopyTarget of LUN in remote device.
+// remote must be registered as remote device in d.
+func (d *Device) RemoteLUNCopyTarget(ctx context.Context, remote *Device, lunID int) (*LUNCopyTarget, error) {
+	remoteArray, err := d.GetRemoteArrayByDevice(ctx, remote)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get remote device: %w", err)
+	}
+
+	lun, err := remote.GetLUN(ctx, lunID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LUN in remote device: %w", err)
+	}
+
+	return &LUNCopyTarget{
+		RemoteArrayID: remoteArray.ID,
+		LUNID:         lun.ID,
+		LUNWWN:        lun.WWN,
+	}, nil
+}
+
+// LUNCopyOption is optional parameter of CreateLUNCopyWithOption
+type LUNCopyOption struct {
+	// Name is "LUNCopy_<source>_<target>" if empty.
+	Name        string
+	Description string
+	// Type is LUNCopyTypeFull if zero.
+	Type int
+	// Speed is SpeedHighest if zero.
+	Speed int
+}
+
+func (o *LUNCopyOption) copyType() int {
+	if o == nil || o.Type == 0 {
+		return LUNCopyTypeFull
+	}
+	return o.Type
+}
+
+func (o *LUNCopyOption) speed() int {
+	if o == nil || o.Speed == 0 {
+		return SpeedHighest
+	}
+	return o.Speed
+}
+
 // CreateLUNCopy create lun copy definition of source to target lun
 func (d *Device) CreateLUNCopy(ctx context.Context, sourceLUNID, targetLUNID int) (*LunCopy, error) {
+	return d.CreateLUNCopyWithOption(ctx, sourceLUNID, []LUNCopyTarget{LocalLUNCopyTarget(targetLUNID)}, nil)
+}
+
+// CreateLUNCopyWithOption create lun copy definition of source to targets
+func (d *Device) CreateLUNCopyWithOption(ctx context.Context, sourceLUNID int, targets []LUNCopyTarget, opts *LUNCopyOption) (*LunCopy, error) {
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("targets must be set")
+	}
+	if t := opts.copyType(); t != LUNCopyTypeFull && t != LUNCopyTypeIncremental {
+		return nil, fmt.Errorf("invalid LUN copy type: %d", t)
+	}
+	if speed := opts.speed(); speed < SpeedLow || speed > SpeedHighest {
+		return nil, fmt.Errorf("invalid speed: %d", speed)
+	}
+
+	var targetLUNs []string
+	for _, target := range targets {
+		if target.RemoteArrayID != "" && target.LUNWWN == "" {
+			return nil, fmt.Errorf("LUNWWN of remote target (LUN ID: %d) must be set", target.LUNID)
+		}
+		targetLUNs = append(targetLUNs, target.String())
+	}
+
+	name := fmt.Sprintf("LUNCopy_%d_%d", sourceLUNID, targets[0].LUNID)
+	if opts != nil && opts.Name != "" {
+		name = opts.Name
+	}
+	description := ""
+	if opts != nil {
+		description = opts.Description
+	}
+
 	spath := "/luncopy"
 	param := struct {
-		NAME      string `json:"NAME"`
-		SOURCELUN string `json:"SOURCELUN"`
-		TARGETLUN string `json:"TARGETLUN"`
-		COPYSPEED int    `json:"COPYSPEED"`
+		NAME        string `json:"NAME"`
+		DESCRIPTION string `json:"DESCRIPTION,omitempty"`
+		SOURCELUN   string `json:"SOURCELUN"`
+		TARGETLUN   string `json:"TARGETLUN"`
+		COPYSPEED   int    `json:"COPYSPEED"`
+		LUNCOPYTYPE int    `json:"LUNCOPYTYPE"`
 	}{
-		NAME:      fmt.Sprintf("LUNCopy_%d_%d", sourceLUNID, targetLUNID),
-		SOURCELUN: fmt.Sprintf("INVALID;%d;INVALID;INVALID;INVALID", sourceLUNID),
-		TARGETLUN: fmt.Sprintf("INVALID;%d;INVALID;INVALID;INVALID", targetLUNID),
-		COPYSPEED: 4,
+		NAME:        name,
+		DESCRIPTION: description,
+		SOURCELUN:   LocalLUNCopyTarget(sourceLUNID).String(),
+		TARGETLUN:   strings.Join(targetLUNs, ","),
+		COPYSPEED:   opts.speed(),
+		LUNCOPYTYPE: opts.copyType(),
 	}
 	jb, err := json.Marshal(param)
 	if err != nil {
@@ -103,6 +213,36 @@ func (d *Device) CreateLUNCopy(ctx context.Context, sourceLUNID, targetLUNID int
 	return lunCopy, nil
 }
 
+// SetLUNCopySpeed change speed (SpeedLow to SpeedHighest) of lun copy.
+func (d *Device) SetLUNCopySpeed(ctx context.Context, luncopyID, speed int) error {
+	if speed < SpeedLow || speed > SpeedHighest {
+		return fmt.Errorf("invalid speed: %d", speed)
+	}
+
+	spath := fmt.Sprintf("/luncopy/%d", luncopyID)
+	param := struct {
+		COPYSPEED string `json:"COPYSPEED"`
+	}{
+		COPYSPEED: strconv.Itoa(speed),
+	}
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+
+	req, err := d.newRequest(ctx, "PUT", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
 // DeleteLUNCopy delete lun copy object
 func (d *Device) DeleteLUNCopy(ctx context.Context, luncopyID int) error {
 	spath := fmt.Sprintf("/luncopy/%d", luncopyID)
@@ -122,7 +262,25 @@ func (d *Device) DeleteLUNCopy(ctx context.Context, luncopyID int) error {
 
 // StartLUNCopy start to copy lun
 func (d *Device) StartLUNCopy(ctx context.Context, luncopyID int) error {
-	spath := "/luncopy/start"
+	return d.operateLUNCopy(ctx, "/luncopy/start", luncopyID)
+}
+
+// StopLUNCopy stop to copy lun. data of target LUN is incomplete.
+func (d *Device) StopLUNCopy(ctx context.Context, luncopyID int) error {
+	return d.operateLUNCopy(ctx, "/luncopy/stop", luncopyID)
+}
+
+// PauseLUNCopy pause to copy lun
+func (d *Device) PauseLUNCopy(ctx context.Context, luncopyID int) error {
+	return d.operateLUNCopy(ctx, "/luncopy/pause", luncopyID)
+}
+
+// ResumeLUNCopy resume paused lun copy
+func (d *Device) ResumeLUNCopy(ctx context.Context, luncopyID int) error {
+	return d.operateLUNCopy(ctx, "/luncopy/resume", luncopyID)
+}
+
+func (d *Device) operateLUNCopy(ctx context.Context, spath string, luncopyID int) error {
 	param := struct {
 		TYPE string `json:"TYPE"`
 		ID   string `json:"ID"`
@@ -148,47 +306,119 @@ func (d *Device) StartLUNCopy(ctx context.Context, luncopyID int) error {
 	return nil
 }
 
-// StartLUNCopyWithWait start luncopy and wait to copy
-func (d *Device) StartLUNCopyWithWait(ctx context.Context, luncopyID int, timeoutCount int) error {
-	if timeoutCount == 0 {
-		timeoutCount = DefaultCopyTimeoutSecond
+// Default values of WaitLUNCopy
+var (
+	DefaultLUNCopyTimeout  = time.Duration(DefaultCopyTimeoutSecond) * time.Second
+	DefaultLUNCopyInterval = 1 * time.Second
+)
+
+// LUNCopyProgress is progress of lun copy
+type LUNCopyProgress struct {
+	LUNCopyID     int
+	RunningStatus int
+	Done          bool
+	Progress      int // percent, -1 is unknown
+	Speed         int // -1 is unknown
+}
+
+// GetProgress return LUNCopyProgress of lun copy
+func (l *LunCopy) GetProgress() LUNCopyProgress {
+	status := parseIntOrUnknown(l.RUNNINGSTATUS)
+	progress := parseIntOrUnknown(l.COPYPROGRESS)
+	if status == StatusLunCopyReady {
+		progress = 100
 	}
 
-	err := d.StartLUNCopy(ctx, luncopyID)
-	if err != nil {
-		return fmt.Errorf("failed to start luncopy (ID: %d): %w", luncopyID, err)
+	return LUNCopyProgress{
+		LUNCopyID:     l.ID,
+		RunningStatus: status,
+		Done:          status == StatusLunCopyReady,
+		Progress:      progress,
+		Speed:         parseIntOrUnknown(l.COPYSPEED),
 	}
+}
 
-	// wait 60 seconds (default)
-	for i := 0; i < timeoutCount; i++ {
-		isReady, err := d.luncopyIsDone(ctx, luncopyID)
-		if err != nil {
-			return fmt.Errorf("failed to wait that luncopy is done: %w", err)
-		}
+// WaitLUNCopyOption is optional parameter of WaitLUNCopy
+type WaitLUNCopyOption struct {
+	// Timeout is DefaultLUNCopyTimeout if zero.
+	Timeout time.Duration
+	// Interval is DefaultLUNCopyInterval if zero.
+	Interval time.Duration
+	// ProgressFunc is called per polling if set.
+	ProgressFunc func(LUNCopyProgress)
+}
 
-		if isReady == true {
-			return nil
-		}
+func (o *WaitLUNCopyOption) timeout() time.Duration {
+	if o == nil || o.Timeout == 0 {
+		return DefaultLUNCopyTimeout
+	}
+	return o.Timeout
+}
 
-		time.Sleep(1 * time.Second)
+func (o *WaitLUNCopyOption) interval() time.Duration {
+	if o == nil || o.Interval == 0 {
+		return DefaultLUNCopyInterval
 	}
+	return o.Interval
+}
 
-	return ErrTimeoutWait
+func (o *WaitLUNCopyOption) report(p LUNCopyProgress) {
+	if o == nil || o.ProgressFunc == nil {
+		return
+	}
+	o.ProgressFunc(p)
+}
+
+// StartLUNCopyWithWait start luncopy and wait to copy up to timeoutCount seconds.
+// timeoutCount is DefaultCopyTimeoutSecond if zero.
+func (d *Device) StartLUNCopyWithWait(ctx context.Context, luncopyID int, timeoutCount int) error {
+	return d.StartLUNCopyWithWaitOption(ctx, luncopyID, &WaitLUNCopyOption{
+		Timeout:  time.Duration(timeoutCount) * time.Second,
+		Interval: time.Second,
+	})
 }
 
-func (d *Device) luncopyIsDone(ctx context.Context, luncopyID int) (bool, error) {
-	luncopy, err := d.GetLUNCopy(ctx, luncopyID)
+// StartLUNCopyWithWaitOption start luncopy and wait to copy by option
+func (d *Device) StartLUNCopyWithWaitOption(ctx context.Context, luncopyID int, opts *WaitLUNCopyOption) error {
+	err := d.StartLUNCopy(ctx, luncopyID)
 	if err != nil {
-		return false, fmt.Errorf("failed to get luncopy (ID: %d): %w", luncopyID, err)
+		return fmt.Errorf("failed to start luncopy (ID: %d): %w", luncopyID, err)
 	}
 
-	if luncopy.HEALTHSTATUS != strconv.Itoa(StatusHealth) {
-		return false, fmt.Errorf("luncopy health status is bad (HEALTHSTATUS: %s)", luncopy.HEALTHSTATUS)
-	}
+	return d.WaitLUNCopy(ctx, luncopyID, opts)
+}
 
-	if luncopy.RUNNINGSTATUS == strconv.Itoa(StatusLunCopyReady) {
-		return true, nil
-	}
+// WaitLUNCopy wait to finish lun copy.
+// return error immediately if lun copy is not healthy or stopped.
+func (d *Device) WaitLUNCopy(ctx context.Context, luncopyID int, opts *WaitLUNCopyOption) error {
+	timeout := time.After(opts.timeout())
+	ticker := time.NewTicker(opts.interval())
+	defer ticker.Stop()
 
-	return false, nil
+	for {
+		luncopy, err := d.GetLUNCopy(ctx, luncopyID)
+		if err != nil {
+			return fmt.Errorf("failed to get luncopy (ID: %d): %w", luncopyID, err)
+		}
+		progress := luncopy.GetProgress()
+		opts.report(progress)
+
+		if luncopy.HEALTHSTATUS != strconv.Itoa(StatusHealth) {
+			return fmt.Errorf("luncopy health status is bad (HEALTHSTATUS: %s)", luncopy.HEALTHSTATUS)
+		}
+		if progress.Done {
+			return nil
+		}
+		if progress.RunningStatus == StatusLunCopyStopped {
+			return fmt.Errorf("luncopy (ID: %d) is stopped", luncopyID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			return ErrTimeoutWait
+		case <-ticker.C:
+		}
+	}
 }
@@ -2,10 +2,12 @@ package dorado
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestDevice_GetLUNCopys(t *testing.T) {
@@ -80,3 +82,95 @@ func TestDevice_GetLUNCopys(t *testing.T) {
 		t.Errorf("GetLUNCopys return %+v, want %+v", luncopys, want)
 	}
 }
+
+func TestDevice_CreateLUNCopyWithOption(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/luncopy", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var param map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
+			t.Fatalf("failed to decode request: %s", err)
+		}
+
+		want := map[string]interface{}{
+			"NAME":        "LUNCopy_148_151",
+			"SOURCELUN":   "INVALID;148;INVALID;INVALID;INVALID",
+			"TARGETLUN":   "INVALID;151;INVALID;INVALID;INVALID,2;60;6a400e210055e22650d557a00000003c;INVALID;INVALID",
+			"COPYSPEED":   float64(SpeedLow),
+			"LUNCOPYTYPE": float64(LUNCopyTypeIncremental),
+		}
+		if !reflect.DeepEqual(param, want) {
+			t.Errorf("request body %+v, want %+v", param, want)
+		}
+		fmt.Fprint(w, `{"data": {"ID": "53", "LUNCOPYTYPE": "2", "TYPE": 219}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	targets := []LUNCopyTarget{
+		LocalLUNCopyTarget(151),
+		{RemoteArrayID: "2", LUNID: 60, LUNWWN: "6a400e210055e22650d557a00000003c"},
+	}
+	luncopy, err := client.LocalDevice.CreateLUNCopyWithOption(context.Background(), 148, targets, &LUNCopyOption{
+		Type:  LUNCopyTypeIncremental,
+		Speed: SpeedLow,
+	})
+	if err != nil {
+		t.Fatalf("CreateLUNCopyWithOption return err: %s", err)
+	}
+	if luncopy.ID != 53 {
+		t.Errorf("CreateLUNCopyWithOption return ID %d, want 53", luncopy.ID)
+	}
+
+	if _, err := client.LocalDevice.CreateLUNCopyWithOption(context.Background(), 148, []LUNCopyTarget{{RemoteArrayID: "2", LUNID: 60}}, nil); err == nil {
+		t.Errorf("CreateLUNCopyWithOption must return err if LUNWWN of remote target is not set")
+	}
+}
+
+func TestDevice_WaitLUNCopy(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	responses := []string{
+		`{"data": {"ID": "53", "COPYPROGRESS": "30", "COPYSPEED": "2", "HEALTHSTATUS": "1", "RUNNINGSTATUS": "39", "TYPE": 219}, "error": {"code": 0, "description": "0"}}`,
+		`{"data": {"ID": "53", "COPYPROGRESS": "-1", "COPYSPEED": "2", "HEALTHSTATUS": "1", "RUNNINGSTATUS": "40", "TYPE": 219}, "error": {"code": 0, "description": "0"}}`,
+	}
+	count := 0
+	mux.HandleFunc("/luncopy/53", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, responses[count])
+		if count < len(responses)-1 {
+			count++
+		}
+	})
+
+	var got []int
+	err := client.LocalDevice.WaitLUNCopy(context.Background(), 53, &WaitLUNCopyOption{
+		Interval: 10 * time.Millisecond,
+		ProgressFunc: func(p LUNCopyProgress) {
+			got = append(got, p.Progress)
+		},
+	})
+	if err != nil {
+		t.Fatalf("WaitLUNCopy return err: %s", err)
+	}
+
+	want := []int{30, 100}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WaitLUNCopy reported %v, want %v", got, want)
+	}
+}
+
+func TestDevice_WaitLUNCopy_Stopped(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/luncopy/53", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "53", "COPYPROGRESS": "30", "HEALTHSTATUS": "1", "RUNNINGSTATUS": "38", "TYPE": 219}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	if err := client.LocalDevice.WaitLUNCopy(context.Background(), 53, nil); err == nil {
+		t.Errorf("WaitLUNCopy must return err if luncopy is stopped")
+	}
+}
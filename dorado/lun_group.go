@@ -157,6 +157,104 @@ func (d *Device) DisAssociateLun(ctx context.Context, lungroupID, lunID int) err
 	return nil
 }
 
+// AssociateLunWithHostLUNID associate lun to lun group and request host LUN ID.
+func (d *Device) AssociateLunWithHostLUNID(ctx context.Context, lungroupID, lunID, hostLUNID int) error {
+	spath := "/lungroup/associate"
+	param := struct {
+		AssociateParam
+		STARTHOSTLUNID string `json:"startHostLunId"`
+	}{
+		AssociateParam: AssociateParam{
+			ID:               strconv.Itoa(lungroupID),
+			ASSOCIATEOBJID:   strconv.Itoa(lunID),
+			ASSOCIATEOBJTYPE: TypeLUN,
+		},
+		STARTHOSTLUNID: strconv.Itoa(hostLUNID),
+	}
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+
+	req, err := d.newRequest(ctx, "POST", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// AssociateLuns associate multiple luns to lun group in one request
+func (d *Device) AssociateLuns(ctx context.Context, lungroupID int, lunIDs []int) error {
+	spath := "/lungroup/associate"
+	param := struct {
+		ID                 string   `json:"ID"`
+		ASSOCIATEOBJTYPE   int      `json:"ASSOCIATEOBJTYPE"`
+		ASSOCIATEOBJIDLIST []string `json:"ASSOCIATEOBJIDLIST"`
+	}{
+		ID:                 strconv.Itoa(lungroupID),
+		ASSOCIATEOBJTYPE:   TypeLUN,
+		ASSOCIATEOBJIDLIST: toStringIDs(lunIDs),
+	}
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+
+	req, err := d.newRequest(ctx, "POST", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// DisAssociateLuns dis associate multiple luns from lun group in one request
+func (d *Device) DisAssociateLuns(ctx context.Context, lungroupID int, lunIDs []int) error {
+	spath := "/lungroup/associate"
+
+	jb, err := json.Marshal(toStringIDs(lunIDs))
+	if err != nil {
+		return fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+
+	req, err := d.newRequest(ctx, "DELETE", spath, nil)
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+	q := req.URL.Query()
+	q.Add("ID", strconv.Itoa(lungroupID))
+	q.Add("ASSOCIATEOBJTYPE", strconv.Itoa(TypeLUN))
+	q.Add("ASSOCIATEOBJIDLIST", string(jb))
+	req.URL.RawQuery = q.Encode()
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+func toStringIDs(ids []int) []string {
+	var s []string
+	for _, id := range ids {
+		s = append(s, strconv.Itoa(id))
+	}
+
+	return s
+}
+
 // GetAssociateLunGroups get associated lun group by query
 func (d *Device) GetAssociateLunGroups(ctx context.Context, query *SearchQuery) ([]LunGroup, error) {
 	spath := "/lungroup/associate"
@@ -0,0 +1,369 @@
+package dorado
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Default values of WaitLUNMigration
+var (
+	DefaultLUNMigrationTimeout  = 12 * time.Hour
+	DefaultLUNMigrationInterval = 10 * time.Second
+)
+
+// LUNMigration is SmartMigration task that move LUN to other LUN (e.g. in other storage pool)
+type LUNMigration struct {
+	DESCRIPTION   string `json:"DESCRIPTION"`
+	HEALTHSTATUS  string `json:"HEALTHSTATUS"`
+	ID            int    `json:"ID,string"`
+	NAME          string `json:"NAME"`
+	PARENTID      int    `json:"PARENTID,string"`
+	PARENTNAME    string `json:"PARENTNAME"`
+	PROCESS       string `json:"PROCESS"`
+	RUNNINGSTATUS string `json:"RUNNINGSTATUS"`
+	SPEED         string `json:"SPEED"`
+	TARGETLUNID   int    `json:"TARGETLUNID,string"`
+	TARGETLUNNAME string `json:"TARGETLUNNAME"`
+	TYPE          int    `json:"TYPE"`
+	WORKMODE      string `json:"WORKMODE"`
+}
+
+// LUNMigrationProgress is progress of LUN migration
+type LUNMigrationProgress struct {
+	LUNMigrationID int
+	RunningStatus  int
+	Completed      bool
+	Progress       int // percent, -1 is unknown
+	Speed          int // -1 is unknown
+}
+
+// GetProgress return LUNMigrationProgress of LUN migration
+func (m *LUNMigration) GetProgress() LUNMigrationProgress {
+	status := parseIntOrUnknown(m.RUNNINGSTATUS)
+	progress := parseIntOrUnknown(m.PROCESS)
+	if status == StatusLunMigrationComplete {
+		progress = 100
+	}
+
+	return LUNMigrationProgress{
+		LUNMigrationID: m.ID,
+		RunningStatus:  status,
+		Completed:      status == StatusLunMigrationComplete,
+		Progress:       progress,
+		Speed:          parseIntOrUnknown(m.SPEED),
+	}
+}
+
+// WaitLUNMigrationOption is optional parameter of WaitLUNMigration
+type WaitLUNMigrationOption struct {
+	// Timeout is DefaultLUNMigrationTimeout if zero.
+	Timeout time.Duration
+	// Interval is DefaultLUNMigrationInterval if zero.
+	Interval time.Duration
+	// ProgressFunc is called per polling if set.
+	ProgressFunc func(LUNMigrationProgress)
+}
+
+func (o *WaitLUNMigrationOption) timeout() time.Duration {
+	if o == nil || o.Timeout == 0 {
+		return DefaultLUNMigrationTimeout
+	}
+	return o.Timeout
+}
+
+func (o *WaitLUNMigrationOption) interval() time.Duration {
+	if o == nil || o.Interval == 0 {
+		return DefaultLUNMigrationInterval
+	}
+	return o.Interval
+}
+
+func (o *WaitLUNMigrationOption) report(p LUNMigrationProgress) {
+	if o == nil || o.ProgressFunc == nil {
+		return
+	}
+	o.ProgressFunc(p)
+}
+
+// GetLUNMigrations get LUN migrations by query
+func (d *Device) GetLUNMigrations(ctx context.Context, query *SearchQuery) ([]LUNMigration, error) {
+	spath := "/LUN_MIGRATION"
+
+	req, err := d.newRequest(ctx, "GET", spath, nil)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+	req = AddSearchQuery(req, query)
+
+	var migrations []LUNMigration
+	if err = d.requestWithRetry(req, &migrations, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	if len(migrations) == 0 {
+		return nil, ErrLunMigrationNotFound
+	}
+
+	return migrations, nil
+}
+
+// GetLUNMigration get LUN migration by id
+func (d *Device) GetLUNMigration(ctx context.Context, migrationID int) (*LUNMigration, error) {
+	spath := fmt.Sprintf("/LUN_MIGRATION/%d", migrationID)
+
+	req, err := d.newRequest(ctx, "GET", spath, nil)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	migration := &LUNMigration{}
+	if err = d.requestWithRetry(req, migration, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return migration, nil
+}
+
+// CreateLUNMigration create LUN migration of source LUN to new LUN in storage pool by speed (SpeedLow to SpeedHighest).
+// source LUN keep ID and host access, and data is moved to storage pool after SplitLUNMigration.
+func (d *Device) CreateLUNMigration(ctx context.Context, sourceLUNID int, storagePoolName string, speed int) (*LUNMigration, error) {
+	if speed < SpeedLow || speed > SpeedHighest {
+		return nil, fmt.Errorf("invalid speed: %d", speed)
+	}
+
+	targetLUN, err := d.createMigrationTargetLUN(ctx, sourceLUNID, storagePoolName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create target LUN: %w", err)
+	}
+
+	migration, err := d.CreateLUNMigrationToLUN(ctx, sourceLUNID, targetLUN.ID, speed)
+	if err != nil {
+		if err := d.DeleteLUN(ctx, targetLUN.ID); err != nil {
+			d.Logger.Printf("failed to delete LUN: %v", err)
+		}
+		return nil, err
+	}
+
+	return migration, nil
+}
+
+// createMigrationTargetLUN create LUN that has same capacity and properties of source LUN.
+func (d *Device) createMigrationTargetLUN(ctx context.Context, sourceLUNID int, storagePoolName string) (*LUN, error) {
+	source, err := d.GetLUN(ctx, sourceLUNID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source LUN: %w", err)
+	}
+	if source.PARENTNAME == storagePoolName {
+		return nil, fmt.Errorf("LUN (ID: %d) is already in storage pool %s", sourceLUNID, storagePoolName)
+	}
+
+	storagePoolID, err := d.getStoragePoolID(ctx, storagePoolName)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := lunSpecFromLUN(source)
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate properties of source LUN: %w", err)
+	}
+
+	p := newParamCreateLUN(fmt.Sprintf("migration_%d", sourceLUNID), storagePoolID, source.DESCRIPTION, source.CAPACITY)
+	spec.apply(&p)
+
+	return d.createLUN(ctx, p)
+}
+
+// CreateLUNMigrationToLUN create LUN migration of source LUN to target LUN.
+// target LUN must have same capacity and not be mapped.
+func (d *Device) CreateLUNMigrationToLUN(ctx context.Context, sourceLUNID, targetLUNID, speed int) (*LUNMigration, error) {
+	if speed < SpeedLow || speed > SpeedHighest {
+		return nil, fmt.Errorf("invalid speed: %d", speed)
+	}
+
+	spath := "/LUN_MIGRATION"
+	param := struct {
+		TYPE        int    `json:"TYPE"`
+		PARENTID    string `json:"PARENTID"`
+		TARGETLUNID string `json:"TARGETLUNID"`
+		SPEED       int    `json:"SPEED"`
+		WORKMODE    int    `json:"WORKMODE"`
+	}{
+		TYPE:        TypeLUNMigration,
+		PARENTID:    strconv.Itoa(sourceLUNID),
+		TARGETLUNID: strconv.Itoa(targetLUNID),
+		SPEED:       speed,
+		WORKMODE:    0,
+	}
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+
+	req, err := d.newRequest(ctx, "POST", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	migration := &LUNMigration{}
+	if err = d.requestWithRetry(req, migration, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return migration, nil
+}
+
+// WaitLUNMigration wait to complete LUN migration.
+// return error immediately if LUN migration is fault or not healthy.
+func (d *Device) WaitLUNMigration(ctx context.Context, migrationID int, opts *WaitLUNMigrationOption) error {
+	timeout := time.After(opts.timeout())
+	ticker := time.NewTicker(opts.interval())
+	defer ticker.Stop()
+
+	for {
+		migration, err := d.GetLUNMigration(ctx, migrationID)
+		if err != nil {
+			return fmt.Errorf("failed to get LUN migration (ID: %d): %w", migrationID, err)
+		}
+		progress := migration.GetProgress()
+		opts.report(progress)
+
+		if progress.Completed {
+			return nil
+		}
+		if migration.HEALTHSTATUS != strconv.Itoa(StatusHealth) || progress.RunningStatus == StatusLunMigrationFault {
+			return fmt.Errorf("LUN migration is fault (HEALTHSTATUS: %s, RUNNINGSTATUS: %s)", migration.HEALTHSTATUS, migration.RUNNINGSTATUS)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			return ErrTimeoutWait
+		case <-ticker.C:
+		}
+	}
+}
+
+// SplitLUNMigration split completed LUN migration.
+// source LUN is served from target LUN after split, and target LUN object is released by device.
+// return ErrLunMigrationNotFinish if LUN migration is not completed.
+func (d *Device) SplitLUNMigration(ctx context.Context, migrationID int) error {
+	migration, err := d.GetLUNMigration(ctx, migrationID)
+	if err != nil {
+		return fmt.Errorf("failed to get LUN migration: %w", err)
+	}
+	if !migration.GetProgress().Completed {
+		return fmt.Errorf("can't split LUN migration (ID: %d): %w", migrationID, ErrLunMigrationNotFinish)
+	}
+
+	return d.deleteLUNMigration(ctx, migrationID)
+}
+
+// CancelLUNMigration cancel LUN migration that is not completed, and delete target LUN.
+// source LUN is not changed.
+func (d *Device) CancelLUNMigration(ctx context.Context, migrationID int) error {
+	migration, err := d.GetLUNMigration(ctx, migrationID)
+	if err != nil {
+		return fmt.Errorf("failed to get LUN migration: %w", err)
+	}
+	if migration.GetProgress().Completed {
+		return fmt.Errorf("LUN migration (ID: %d) is already completed, use SplitLUNMigration", migrationID)
+	}
+
+	if err := d.deleteLUNMigration(ctx, migrationID); err != nil {
+		return err
+	}
+	if err := d.DeleteLUN(ctx, migration.TARGETLUNID); err != nil {
+		return fmt.Errorf("failed to delete target LUN: %w", err)
+	}
+
+	return nil
+}
+
+func (d *Device) deleteLUNMigration(ctx context.Context, migrationID int) error {
+	spath := fmt.Sprintf("/LUN_MIGRATION/%d", migrationID)
+	req, err := d.newRequest(ctx, "DELETE", spath, nil)
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// MigrateLUN move LUN to storage pool and wait. LUN is online while migration.
+// LUN migration is canceled if failed to wait, even if ctx is done.
+func (d *Device) MigrateLUN(ctx context.Context, lunID int, storagePoolName string, speed int, opts *WaitLUNMigrationOption) error {
+	migration, err := d.CreateLUNMigration(ctx, lunID, storagePoolName, speed)
+	if err != nil {
+		return fmt.Errorf("failed to create LUN migration: %w", err)
+	}
+
+	if err := d.WaitLUNMigration(ctx, migration.ID, opts); err != nil {
+		// ctx may be canceled or timed out, cancel migration by other context
+		if err := d.CancelLUNMigration(context.Background(), migration.ID); err != nil {
+			d.Logger.Printf("failed to cancel LUN migration: %v", err)
+		}
+		return fmt.Errorf("failed to wait LUN migration: %w", err)
+	}
+
+	if err := d.SplitLUNMigration(ctx, migration.ID); err != nil {
+		return fmt.Errorf("failed to split LUN migration: %w", err)
+	}
+
+	return nil
+}
+
+// MigrateVolume move both LUNs of HyperMetroPair to storage pool that has same name in each device.
+// volume is online while migration. LUN that is already in storage pool is skipped.
+// opts is shared by both devices, so ProgressFunc may be called concurrently.
+func (c *Client) MigrateVolume(ctx context.Context, hyperMetroPairID, storagePoolName string, speed int, opts *WaitLUNMigrationOption) error {
+	if speed < SpeedLow || speed > SpeedHighest {
+		return fmt.Errorf("invalid speed: %d", speed)
+	}
+	if err := c.requireBothDevices(ctx); err != nil {
+		return err
+	}
+
+	hmp, err := c.GetHyperMetroPair(ctx, hyperMetroPairID)
+	if err != nil {
+		return fmt.Errorf("failed to get HyperMetroPair: %w", err)
+	}
+
+	eg := errgroup.Group{}
+	for _, side := range []DeviceSide{SideLocal, SideRemote} {
+		side := side
+		eg.Go(func() error {
+			d := c.device(side)
+			lunID := hmp.lunID(side)
+
+			lun, err := d.GetLUN(ctx, lunID)
+			if err != nil {
+				return fmt.Errorf("failed to get LUN in %s: %w", side, err)
+			}
+			if lun.PARENTNAME == storagePoolName {
+				return nil
+			}
+
+			if err := d.MigrateLUN(ctx, lunID, storagePoolName, speed, opts); err != nil {
+				return fmt.Errorf("failed to migrate LUN in %s: %w", side, err)
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return fmt.Errorf("failed to migrate volume: %w", err)
+	}
+
+	return nil
+}
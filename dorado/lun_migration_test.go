@@ -0,0 +1,222 @@
+package dorado
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDevice_CreateLUNMigrationToLUN(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/LUN_MIGRATION", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var param map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
+			t.Fatalf("failed to decode request: %s", err)
+		}
+
+		want := map[string]interface{}{
+			"TYPE":        float64(TypeLUNMigration),
+			"PARENTID":    "6",
+			"TARGETLUNID": "9",
+			"SPEED":       float64(SpeedLow),
+			"WORKMODE":    float64(0),
+		}
+		if !reflect.DeepEqual(param, want) {
+			t.Errorf("request body %+v, want %+v", param, want)
+		}
+		fmt.Fprint(w, `{"data": {"ID": "3", "PARENTID": "6", "TARGETLUNID": "9", "RUNNINGSTATUS": "75", "TYPE": 253}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	migration, err := client.LocalDevice.CreateLUNMigrationToLUN(context.Background(), 6, 9, SpeedLow)
+	if err != nil {
+		t.Fatalf("CreateLUNMigrationToLUN return err: %s", err)
+	}
+	if migration.ID != 3 || migration.TARGETLUNID != 9 {
+		t.Errorf("CreateLUNMigrationToLUN return %+v, want ID 3 and target 9", migration)
+	}
+}
+
+func TestDevice_CreateLUNMigration_TargetProperties(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/lun/6", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "6", "ALLOCTYPE": "1", "CAPACITY": "2097152", "DESCRIPTION": "volume-1", "ENABLECOMPRESSION": "true", "ENABLESMARTDEDUP": "false", "OWNINGCONTROLLER": "0B", "PARENTNAME": "pool1", "SECTORSIZE": "4096", "WORKLOADTYPEID": "2", "TYPE": 11}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/storagepool", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": [{"ID": "1", "NAME": "pool2"}], "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/lun", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var param map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
+			t.Fatalf("failed to decode request: %s", err)
+		}
+
+		want := map[string]interface{}{
+			"NAME":              "migration_6",
+			"PARENTID":          "1",
+			"CAPACITY":          float64(2097152),
+			"DESCRIPTION":       "volume-1",
+			"ALLOCTYPE":         float64(AllocTypeThin),
+			"ENABLESMARTDEDUP":  false,
+			"ENABLECOMPRESSION": true,
+			"OWNINGCONTROLLER":  "0B",
+			"SECTORSIZE":        "4096",
+			"WORKLOADTYPEID":    "2",
+		}
+		for key, value := range want {
+			if param[key] != value {
+				t.Errorf("request %s = %v, want %v", key, param[key], value)
+			}
+		}
+		fmt.Fprint(w, `{"data": {"ID": "9", "TYPE": 11}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/LUN_MIGRATION", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"data": {"ID": "3", "PARENTID": "6", "TARGETLUNID": "9", "RUNNINGSTATUS": "75", "TYPE": 253}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	migration, err := client.LocalDevice.CreateLUNMigration(context.Background(), 6, "pool2", SpeedMedium)
+	if err != nil {
+		t.Fatalf("CreateLUNMigration return err: %s", err)
+	}
+	if migration.TARGETLUNID != 9 {
+		t.Errorf("CreateLUNMigration return target %d, want 9", migration.TARGETLUNID)
+	}
+}
+
+func TestDevice_WaitLUNMigration(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	responses := []string{
+		`{"data": {"ID": "3", "HEALTHSTATUS": "1", "PROCESS": "40", "RUNNINGSTATUS": "75", "SPEED": "2", "TYPE": 253}, "error": {"code": 0, "description": "0"}}`,
+		`{"data": {"ID": "3", "HEALTHSTATUS": "1", "PROCESS": "", "RUNNINGSTATUS": "76", "SPEED": "2", "TYPE": 253}, "error": {"code": 0, "description": "0"}}`,
+	}
+	count := 0
+	mux.HandleFunc("/LUN_MIGRATION/3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, responses[count])
+		if count < len(responses)-1 {
+			count++
+		}
+	})
+
+	var got []int
+	err := client.LocalDevice.WaitLUNMigration(context.Background(), 3, &WaitLUNMigrationOption{
+		Interval: 10 * time.Millisecond,
+		ProgressFunc: func(p LUNMigrationProgress) {
+			got = append(got, p.Progress)
+		},
+	})
+	if err != nil {
+		t.Fatalf("WaitLUNMigration return err: %s", err)
+	}
+
+	want := []int{40, 100}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WaitLUNMigration reported %v, want %v", got, want)
+	}
+}
+
+func TestDevice_MigrateLUN_CancelAfterContextDone(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/lun/6", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "6", "ALLOCTYPE": "1", "CAPACITY": "2097152", "PARENTNAME": "pool1", "TYPE": 11}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/storagepool", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": [{"ID": "1", "NAME": "pool2"}], "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/lun", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"data": {"ID": "9", "TYPE": 11}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/LUN_MIGRATION", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"data": {"ID": "3", "PARENTID": "6", "TARGETLUNID": "9", "RUNNINGSTATUS": "75", "TYPE": 253}, "error": {"code": 0, "description": "0"}}`)
+	})
+	var deleted []string
+	mux.HandleFunc("/LUN_MIGRATION/3", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			deleted = append(deleted, r.URL.Path)
+		}
+		fmt.Fprint(w, `{"data": {"ID": "3", "HEALTHSTATUS": "1", "PROCESS": "40", "RUNNINGSTATUS": "75", "TARGETLUNID": "9", "TYPE": 253}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/lun/9", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			deleted = append(deleted, r.URL.Path)
+		}
+		fmt.Fprint(w, `{"data": {"ID": "9", "HEALTHSTATUS": "1", "RUNNINGSTATUS": "27", "ISCLONE": "false", "TYPE": 11}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := client.LocalDevice.MigrateLUN(ctx, 6, "pool2", SpeedMedium, &WaitLUNMigrationOption{
+		ProgressFunc: func(p LUNMigrationProgress) {
+			cancel()
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("MigrateLUN return err: %v, want %v", err, context.Canceled)
+	}
+
+	want := []string{"/LUN_MIGRATION/3", "/lun/9"}
+	if !reflect.DeepEqual(deleted, want) {
+		t.Errorf("MigrateLUN deleted %v, want %v", deleted, want)
+	}
+}
+
+func TestDevice_SplitLUNMigration_NotCompleted(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/LUN_MIGRATION/3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "3", "HEALTHSTATUS": "1", "PROCESS": "40", "RUNNINGSTATUS": "75", "TYPE": 253}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	err := client.LocalDevice.SplitLUNMigration(context.Background(), 3)
+	if !errors.Is(err, ErrLunMigrationNotFinish) {
+		t.Errorf("SplitLUNMigration return err: %v, want %v", err, ErrLunMigrationNotFinish)
+	}
+}
+
+func TestClient_MigrateVolume_AlreadyInPool(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/HyperMetroPair/e4c2d1eaf02c0001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "e4c2d1eaf02c0001", "LOCALOBJID": "216", "REMOTEOBJID": "514", "TYPE": 15361}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/lun/216", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "216", "PARENTNAME": "pool2", "TYPE": 11}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/lun/514", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "514", "PARENTNAME": "pool2", "TYPE": 11}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/LUN_MIGRATION", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("MigrateVolume must not create LUN migration if LUN is already in storage pool")
+	})
+
+	if err := client.MigrateVolume(context.Background(), "e4c2d1eaf02c0001", "pool2", SpeedMedium, nil); err != nil {
+		t.Errorf("MigrateVolume return err: %s", err)
+	}
+}
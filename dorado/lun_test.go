@@ -2,10 +2,13 @@ package dorado
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
 	"testing"
+
+	uuid "github.com/satori/go.uuid"
 )
 
 func TestDevice_GetLUNs(t *testing.T) {
@@ -138,3 +141,73 @@ func TestDevice_GetLUNs(t *testing.T) {
 		t.Errorf("GetLUNs return %+v, want %+v", luns, want)
 	}
 }
+
+func TestLUNSpec_Validate(t *testing.T) {
+	enabled := true
+	tests := []struct {
+		spec  *LUNSpec
+		valid bool
+	}{
+		{spec: nil, valid: true},
+		{spec: &LUNSpec{Thick: true, OwningController: "0A", SectorSize: 4096, WorkloadTypeID: "1"}, valid: true},
+		{spec: &LUNSpec{Thick: true, EnableSmartDedup: &enabled}, valid: false},
+		{spec: &LUNSpec{OwningController: "A0"}, valid: false},
+		{spec: &LUNSpec{SectorSize: 1024}, valid: false},
+		{spec: &LUNSpec{WorkloadTypeID: "oracle"}, valid: false},
+	}
+
+	for _, test := range tests {
+		err := test.spec.Validate()
+		if test.valid && err != nil {
+			t.Errorf("Validate(%+v) return err: %s", test.spec, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("Validate(%+v) must return err", test.spec)
+		}
+	}
+}
+
+func TestDevice_CreateLUN_Spec(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/storagepool", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": [{"ID": "0", "NAME": "StoragePool001"}], "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/lun", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var param map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
+			t.Fatalf("failed to decode request: %s", err)
+		}
+
+		want := map[string]interface{}{
+			"ALLOCTYPE":         float64(AllocTypeThin),
+			"ENABLESMARTDEDUP":  false,
+			"OWNINGCONTROLLER":  "0B",
+			"SECTORSIZE":        "4096",
+			"DESCRIPTION":       "gold",
+			"WORKLOADTYPEID":    "2",
+			"ENABLECOMPRESSION": nil,
+		}
+		for key, value := range want {
+			if param[key] != value {
+				t.Errorf("request %s = %v, want %v", key, param[key], value)
+			}
+		}
+		fmt.Fprint(w, `{"data": {"ID": "6", "TYPE": 11}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	disabled := false
+	_, err := client.LocalDevice.CreateLUNWithSpec(context.Background(), uuid.UUID{}, 1, "StoragePool001", &LUNSpec{
+		EnableSmartDedup: &disabled,
+		WorkloadTypeID:   "2",
+		OwningController: "0B",
+		SectorSize:       4096,
+		Description:      "gold",
+	})
+	if err != nil {
+		t.Fatalf("CreateLUN return err: %s", err)
+	}
+}
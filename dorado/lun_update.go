@@ -0,0 +1,170 @@
+package dorado
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// For LUN PREFETCHPOLICY
+const (
+	PrefetchPolicyNone        = 0
+	PrefetchPolicyFixed       = 1
+	PrefetchPolicyVariable    = 2
+	PrefetchPolicyIntelligent = 3
+)
+
+// For LUN WRITEPOLICY
+const (
+	WritePolicyWriteBack    = 1
+	WritePolicyWriteThrough = 2
+)
+
+// LUNUpdate is parameter of UpdateLUN. only non-nil fields are changed.
+type LUNUpdate struct {
+	Name              *string
+	Description       *string
+	EnableSmartDedup  *bool
+	EnableCompression *bool
+	// PrefetchPolicy is one of PrefetchPolicy*.
+	// PrefetchValue is used by PrefetchPolicyFixed (KB) and PrefetchPolicyVariable (multiple).
+	PrefetchPolicy *int
+	PrefetchValue  *int
+	// WritePolicy is WritePolicyWriteBack or WritePolicyWriteThrough.
+	WritePolicy      *int
+	OwningController *string
+}
+
+// Validate validate parameter
+func (u *LUNUpdate) Validate() error {
+	if u.Name == nil && u.Description == nil && u.EnableSmartDedup == nil && u.EnableCompression == nil &&
+		u.PrefetchPolicy == nil && u.PrefetchValue == nil && u.WritePolicy == nil && u.OwningController == nil {
+		return fmt.Errorf("no property to update")
+	}
+
+	if u.Name != nil && (*u.Name == "" || len(*u.Name) > MaxNameLength) {
+		return fmt.Errorf("name must be 1 to %d characters", MaxNameLength)
+	}
+	if u.Description != nil && len(*u.Description) > MaxLUNDescriptionLength {
+		return fmt.Errorf("description must be less than %d characters", MaxLUNDescriptionLength)
+	}
+	if u.PrefetchPolicy != nil && (*u.PrefetchPolicy < PrefetchPolicyNone || *u.PrefetchPolicy > PrefetchPolicyIntelligent) {
+		return fmt.Errorf("invalid prefetch policy: %d", *u.PrefetchPolicy)
+	}
+	if u.PrefetchValue != nil {
+		if u.PrefetchPolicy == nil || (*u.PrefetchPolicy != PrefetchPolicyFixed && *u.PrefetchPolicy != PrefetchPolicyVariable) {
+			return fmt.Errorf("prefetch value can be set only with fixed or variable prefetch policy")
+		}
+		if *u.PrefetchValue < 0 {
+			return fmt.Errorf("invalid prefetch value: %d", *u.PrefetchValue)
+		}
+	}
+	if u.WritePolicy != nil && *u.WritePolicy != WritePolicyWriteBack && *u.WritePolicy != WritePolicyWriteThrough {
+		return fmt.Errorf("invalid write policy: %d", *u.WritePolicy)
+	}
+	if u.OwningController != nil && !owningControllerRegexp.MatchString(*u.OwningController) {
+		return fmt.Errorf("invalid owning controller: %s", *u.OwningController)
+	}
+
+	return nil
+}
+
+func optionalItoa(i *int) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.Itoa(*i)
+}
+
+// UpdateLUN change properties of LUN
+func (d *Device) UpdateLUN(ctx context.Context, lunID int, update LUNUpdate) (*LUN, error) {
+	if err := update.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate LUN update: %w", err)
+	}
+
+	spath := fmt.Sprintf("/lun/%d", lunID)
+	param := struct {
+		ID                string  `json:"ID"`
+		TYPE              int     `json:"TYPE"`
+		NAME              *string `json:"NAME,omitempty"`
+		DESCRIPTION       *string `json:"DESCRIPTION,omitempty"`
+		ENABLESMARTDEDUP  *bool   `json:"ENABLESMARTDEDUP,omitempty"`
+		ENABLECOMPRESSION *bool   `json:"ENABLECOMPRESSION,omitempty"`
+		PREFETCHPOLICY    string  `json:"PREFETCHPOLICY,omitempty"`
+		PREFETCHVALUE     string  `json:"PREFETCHVALUE,omitempty"`
+		WRITEPOLICY       string  `json:"WRITEPOLICY,omitempty"`
+		OWNINGCONTROLLER  *string `json:"OWNINGCONTROLLER,omitempty"`
+	}{
+		ID:                strconv.Itoa(lunID),
+		TYPE:              TypeLUN,
+		NAME:              update.Name,
+		DESCRIPTION:       update.Description,
+		ENABLESMARTDEDUP:  update.EnableSmartDedup,
+		ENABLECOMPRESSION: update.EnableCompression,
+		PREFETCHPOLICY:    optionalItoa(update.PrefetchPolicy),
+		PREFETCHVALUE:     optionalItoa(update.PrefetchValue),
+		WRITEPOLICY:       optionalItoa(update.WritePolicy),
+		OWNINGCONTROLLER:  update.OwningController,
+	}
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+
+	req, err := d.newRequest(ctx, "PUT", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return d.GetLUN(ctx, lunID)
+}
+
+// SideResult is result of operation per device of HyperMetroPair
+type SideResult struct {
+	Side  DeviceSide
+	LUNID int
+	LUN   *LUN // only set if Err is nil
+	Err   error
+}
+
+// UpdateVolume change properties of both LUNs in HyperMetroPair.
+// return error if a device is unreachable or failed to get HyperMetroPair, error of each device is set to SideResult.Err.
+func (c *Client) UpdateVolume(ctx context.Context, hyperMetroPairID string, update LUNUpdate) ([]SideResult, error) {
+	if err := update.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate LUN update: %w", err)
+	}
+	if err := c.requireBothDevices(ctx); err != nil {
+		return nil, err
+	}
+
+	hmp, err := c.GetHyperMetroPair(ctx, hyperMetroPairID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HyperMetroPair: %w", err)
+	}
+
+	sides := []DeviceSide{SideLocal, SideRemote}
+	results := make([]SideResult, len(sides))
+	eg := errgroup.Group{}
+	for i, side := range sides {
+		i, side := i, side
+		results[i] = SideResult{Side: side, LUNID: hmp.lunID(side)}
+
+		eg.Go(func() error {
+			// keep result of other device, so error is returned in SideResult
+			results[i].LUN, results[i].Err = c.device(side).UpdateLUN(ctx, results[i].LUNID, update)
+			return nil
+		})
+	}
+	_ = eg.Wait()
+
+	return results, nil
+}
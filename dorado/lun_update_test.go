@@ -0,0 +1,119 @@
+package dorado
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDevice_UpdateLUN(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/lun/6", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			var param map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
+				t.Fatalf("failed to decode request: %s", err)
+			}
+			want := map[string]interface{}{
+				"ID":                "6",
+				"TYPE":              float64(TypeLUN),
+				"DESCRIPTION":       "gold",
+				"ENABLECOMPRESSION": false,
+				"WRITEPOLICY":       "2",
+			}
+			if !reflect.DeepEqual(param, want) {
+				t.Errorf("request body %+v, want %+v", param, want)
+			}
+			fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+		case "GET":
+			fmt.Fprint(w, `{"data": {"ID": "6", "DESCRIPTION": "gold", "ENABLECOMPRESSION": "false", "WRITEPOLICY": "2", "TYPE": 11}, "error": {"code": 0, "description": "0"}}`)
+		default:
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+	})
+
+	description := "gold"
+	disabled := false
+	writePolicy := WritePolicyWriteThrough
+	lun, err := client.LocalDevice.UpdateLUN(context.Background(), 6, LUNUpdate{
+		Description:       &description,
+		EnableCompression: &disabled,
+		WritePolicy:       &writePolicy,
+	})
+	if err != nil {
+		t.Fatalf("UpdateLUN return err: %s", err)
+	}
+	if lun.DESCRIPTION != "gold" {
+		t.Errorf("UpdateLUN return DESCRIPTION %s, want gold", lun.DESCRIPTION)
+	}
+}
+
+func TestLUNUpdate_Validate(t *testing.T) {
+	longName := "0123456789abcdef0123456789abcdef"
+	policy := PrefetchPolicyIntelligent
+	value := 8
+	controller := "B0"
+	tests := []LUNUpdate{
+		{},
+		{Name: &longName},
+		{PrefetchPolicy: &policy, PrefetchValue: &value},
+		{OwningController: &controller},
+	}
+
+	for _, update := range tests {
+		if err := update.Validate(); err == nil {
+			t.Errorf("Validate(%+v) must return err", update)
+		}
+	}
+}
+
+func TestClient_UpdateVolume(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/HyperMetroPair/e4c2d1eaf02c0001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "e4c2d1eaf02c0001", "LOCALOBJID": "216", "REMOTEOBJID": "514", "TYPE": 15361}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/lun/216", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": {"ID": "216", "OWNINGCONTROLLER": "0B", "TYPE": 11}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/lun/514", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 50331651, "description": "The entered parameter is incorrect."}}`)
+	})
+
+	controller := "0B"
+	results, err := client.UpdateVolume(context.Background(), "e4c2d1eaf02c0001", LUNUpdate{OwningController: &controller})
+	if err != nil {
+		t.Fatalf("UpdateVolume return err: %s", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("UpdateVolume return %d results, want 2", len(results))
+	}
+	if results[0].Side != SideLocal || results[0].LUNID != 216 || results[0].Err != nil || results[0].LUN == nil {
+		t.Errorf("result of local device is %+v, want success of LUN 216", results[0])
+	}
+	if results[1].Side != SideRemote || results[1].LUNID != 514 || results[1].Err == nil {
+		t.Errorf("result of remote device is %+v, want error of LUN 514", results[1])
+	}
+}
+
+func TestClient_UpdateVolume_Degraded(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+	client.degraded = &degradedState{unreachable: SideRemote}
+
+	name := "volume01"
+	_, err := client.UpdateVolume(context.Background(), "e4c2d1eaf02c0001", LUNUpdate{Name: &name})
+	if !errors.Is(err, ErrDegraded) {
+		t.Errorf("UpdateVolume return err: %v, want %v", err, ErrDegraded)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+
+	"github.com/pkg/errors"
 )
 
 // PortGroup is group of Port (ex Ethernet, FiberChannel...)
@@ -53,6 +55,31 @@ func (d *Device) GetPortGroup(ctx context.Context, portgroupID int) (*PortGroup,
 	return portGroup, nil
 }
 
+// GetPortGroupByName get port group by name.
+// name of port group must be unique.
+// NAME filter of REST API match partially, so port groups that have other name are ignored.
+func (d *Device) GetPortGroupByName(ctx context.Context, portgroupName string) (*PortGroup, error) {
+	portgroups, err := d.GetPortGroups(ctx, NewSearchQueryName(portgroupName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portgroup: %w", err)
+	}
+
+	var matched []PortGroup
+	for _, portgroup := range portgroups {
+		if portgroup.NAME == portgroupName {
+			matched = append(matched, portgroup)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("failed to get portgroup: %w", ErrPortGroupNotFound)
+	}
+	if len(matched) > 1 {
+		return nil, errors.New("found multiple portgroup in same PortGroup name")
+	}
+
+	return &matched[0], nil
+}
+
 // GetPortGroupsAssociate get port group that associated by mapping view id
 func (d *Device) GetPortGroupsAssociate(ctx context.Context, mappingviewID int) ([]PortGroup, error) {
 	spath := "/portgroup/associate"
@@ -2,6 +2,7 @@ package dorado
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -50,3 +51,19 @@ func TestDevice_GetPortGroups(t *testing.T) {
 		t.Errorf("GetPortGroups return %+v, want %+v", portgroups, want)
 	}
 }
+
+func TestDevice_GetPortGroupByName_NotFound(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	// filter of REST API match partially, so port group that has other name is returned
+	mux.HandleFunc("/portgroup", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": [{"ID": "1", "NAME": "portgroup2", "TYPE": 257}], "error": {"code": 0, "description": "0"}}`)
+	})
+
+	_, err := client.LocalDevice.GetPortGroupByName(context.Background(), "portgroup")
+	if !errors.Is(err, ErrPortGroupNotFound) {
+		t.Errorf("GetPortGroupByName return err: %v, want %v", err, ErrPortGroupNotFound)
+	}
+}
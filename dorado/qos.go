@@ -0,0 +1,492 @@
+package dorado
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// For QoSPolicy IOTYPE
+const (
+	QoSIOTypeRead      = 0
+	QoSIOTypeWrite     = 1
+	QoSIOTypeReadWrite = 2
+)
+
+// For QoSPolicy SCHEDULEPOLICY
+const (
+	QoSScheduleOnce   = 1
+	QoSScheduleDaily  = 2
+	QoSScheduleWeekly = 3
+)
+
+// QoSPolicy is SmartQoS policy (ioclass)
+type QoSPolicy struct {
+	CYCLESET          string `json:"CYCLESET"`
+	DESCRIPTION       string `json:"DESCRIPTION"`
+	DURATION          string `json:"DURATION"`
+	ENABLESTATUS      string `json:"ENABLESTATUS"`
+	HEALTHSTATUS      string `json:"HEALTHSTATUS"`
+	ID                int    `json:"ID,string"`
+	IOTYPE            string `json:"IOTYPE"`
+	LATENCY           string `json:"LATENCY"`
+	LUNGROUPLIST      string `json:"LUNGROUPLIST"`
+	LUNLIST           string `json:"LUNLIST"`
+	MAXBANDWIDTH      string `json:"MAXBANDWIDTH"`
+	MAXIOPS           string `json:"MAXIOPS"`
+	MINBANDWIDTH      string `json:"MINBANDWIDTH"`
+	MINIOPS           string `json:"MINIOPS"`
+	NAME              string `json:"NAME"`
+	RUNNINGSTATUS     string `json:"RUNNINGSTATUS"`
+	SCHEDULEPOLICY    string `json:"SCHEDULEPOLICY"`
+	SCHEDULESTARTTIME string `json:"SCHEDULESTARTTIME"`
+	STARTTIME         string `json:"STARTTIME"`
+	TYPE              int    `json:"TYPE"`
+}
+
+// LUNIDs return IDs of LUNs that associated QoS policy
+func (q *QoSPolicy) LUNIDs() ([]int, error) {
+	ids, err := parseIDList(q.LUNLIST)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse LUNLIST: %w", err)
+	}
+	return ids, nil
+}
+
+// LunGroupIDs return IDs of LUN groups that associated QoS policy
+func (q *QoSPolicy) LunGroupIDs() ([]int, error) {
+	ids, err := parseIDList(q.LUNGROUPLIST)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse LUNGROUPLIST: %w", err)
+	}
+	return ids, nil
+}
+
+// QoSSchedule is time schedule of QoS policy
+type QoSSchedule struct {
+	// Policy is one of QoSSchedule*.
+	Policy int
+	// Start is first date and time of day to enable policy.
+	Start time.Time
+	// Duration is length of period to enable policy, up to 24 hours.
+	Duration time.Duration
+	// Weekdays is used only Policy is QoSScheduleWeekly.
+	Weekdays []time.Weekday
+}
+
+// QoSSpec is parameter of QoS policy.
+// limit (MaxIOPS, MaxBandwidth) and guarantee (MinIOPS, MinBandwidth, Latency) can't be set at the same time.
+type QoSSpec struct {
+	MaxIOPS      int
+	MinIOPS      int
+	MaxBandwidth int // MB/s
+	MinBandwidth int // MB/s
+	Latency      int // ms
+	// IOType is QoSIOTypeReadWrite if nil.
+	IOType *int
+	// Schedule is all day every day if nil.
+	Schedule *QoSSchedule
+}
+
+// Validate validate parameter
+func (s *QoSSpec) Validate() error {
+	for _, v := range []int{s.MaxIOPS, s.MinIOPS, s.MaxBandwidth, s.MinBandwidth, s.Latency} {
+		if v < 0 {
+			return fmt.Errorf("value of QoS must be positive")
+		}
+	}
+
+	limit := s.MaxIOPS != 0 || s.MaxBandwidth != 0
+	guarantee := s.MinIOPS != 0 || s.MinBandwidth != 0 || s.Latency != 0
+	if !limit && !guarantee {
+		return fmt.Errorf("QoS spec has no limit or guarantee")
+	}
+	if limit && guarantee {
+		return fmt.Errorf("limit and guarantee can't be set at the same time")
+	}
+
+	if s.IOType != nil && (*s.IOType < QoSIOTypeRead || *s.IOType > QoSIOTypeReadWrite) {
+		return fmt.Errorf("invalid IO type: %d", *s.IOType)
+	}
+
+	if schedule := s.Schedule; schedule != nil {
+		switch schedule.Policy {
+		case QoSScheduleOnce, QoSScheduleDaily:
+			if len(schedule.Weekdays) != 0 {
+				return fmt.Errorf("weekdays can be set only in weekly schedule")
+			}
+		case QoSScheduleWeekly:
+			if len(schedule.Weekdays) == 0 {
+				return fmt.Errorf("weekdays must be set in weekly schedule")
+			}
+		default:
+			return fmt.Errorf("invalid schedule policy: %d", schedule.Policy)
+		}
+		if schedule.Duration <= 0 || schedule.Duration > 24*time.Hour {
+			return fmt.Errorf("duration of schedule must be up to 24 hours")
+		}
+		if schedule.Start.IsZero() {
+			return fmt.Errorf("start of schedule must be set")
+		}
+	}
+
+	return nil
+}
+
+// paramQoSPolicy is parameter for CreateQoSPolicy and UpdateQoSPolicy
+type paramQoSPolicy struct {
+	ID                string   `json:"ID,omitempty"`
+	TYPE              int      `json:"TYPE"`
+	NAME              string   `json:"NAME,omitempty"`
+	LUNLIST           []string `json:"LUNLIST,omitempty"`
+	CLASSTYPE         string   `json:"CLASSTYPE,omitempty"`
+	IOTYPE            string   `json:"IOTYPE,omitempty"`
+	MAXIOPS           string   `json:"MAXIOPS,omitempty"`
+	MINIOPS           string   `json:"MINIOPS,omitempty"`
+	MAXBANDWIDTH      string   `json:"MAXBANDWIDTH,omitempty"`
+	MINBANDWIDTH      string   `json:"MINBANDWIDTH,omitempty"`
+	LATENCY           string   `json:"LATENCY,omitempty"`
+	SCHEDULEPOLICY    string   `json:"SCHEDULEPOLICY,omitempty"`
+	SCHEDULESTARTTIME string   `json:"SCHEDULESTARTTIME,omitempty"`
+	STARTTIME         string   `json:"STARTTIME,omitempty"`
+	DURATION          string   `json:"DURATION,omitempty"`
+	CYCLESET          string   `json:"CYCLESET,omitempty"`
+}
+
+func itoaOrEmpty(i int) string {
+	if i == 0 {
+		return ""
+	}
+	return strconv.Itoa(i)
+}
+
+func (s *QoSSpec) toParam(now time.Time) paramQoSPolicy {
+	ioType := QoSIOTypeReadWrite
+	if s.IOType != nil {
+		ioType = *s.IOType
+	}
+
+	schedule := s.Schedule
+	if schedule == nil {
+		start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		schedule = &QoSSchedule{Policy: QoSScheduleDaily, Start: start, Duration: 24 * time.Hour}
+	}
+	date := time.Date(schedule.Start.Year(), schedule.Start.Month(), schedule.Start.Day(), 0, 0, 0, 0, schedule.Start.Location())
+
+	p := paramQoSPolicy{
+		TYPE:              TypeQoSPolicy,
+		CLASSTYPE:         "1",
+		IOTYPE:            strconv.Itoa(ioType),
+		MAXIOPS:           itoaOrEmpty(s.MaxIOPS),
+		MINIOPS:           itoaOrEmpty(s.MinIOPS),
+		MAXBANDWIDTH:      itoaOrEmpty(s.MaxBandwidth),
+		MINBANDWIDTH:      itoaOrEmpty(s.MinBandwidth),
+		LATENCY:           itoaOrEmpty(s.Latency),
+		SCHEDULEPOLICY:    strconv.Itoa(schedule.Policy),
+		SCHEDULESTARTTIME: strconv.FormatInt(date.Unix(), 10),
+		STARTTIME:         schedule.Start.Format("15:04"),
+		DURATION:          strconv.Itoa(int(schedule.Duration.Seconds())),
+	}
+	if schedule.Policy == QoSScheduleWeekly {
+		var days []int
+		for _, day := range schedule.Weekdays {
+			days = append(days, int(day))
+		}
+		jb, _ := json.Marshal(days)
+		p.CYCLESET = string(jb)
+	}
+
+	return p
+}
+
+func toIDList(ids []int) []string {
+	var values []string
+	for _, id := range ids {
+		values = append(values, strconv.Itoa(id))
+	}
+	return values
+}
+
+// GetQoSPolicies get QoS policies by query
+func (d *Device) GetQoSPolicies(ctx context.Context, query *SearchQuery) ([]QoSPolicy, error) {
+	spath := "/ioclass"
+
+	req, err := d.newRequest(ctx, "GET", spath, nil)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+	req = AddSearchQuery(req, query)
+
+	var policies []QoSPolicy
+	if err = d.requestWithRetry(req, &policies, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	if len(policies) == 0 {
+		return nil, ErrQoSPolicyNotFound
+	}
+
+	return policies, nil
+}
+
+// GetQoSPolicy get QoS policy by id
+func (d *Device) GetQoSPolicy(ctx context.Context, policyID int) (*QoSPolicy, error) {
+	spath := fmt.Sprintf("/ioclass/%d", policyID)
+
+	req, err := d.newRequest(ctx, "GET", spath, nil)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	policy := &QoSPolicy{}
+	if err = d.requestWithRetry(req, policy, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return policy, nil
+}
+
+// GetQoSPolicyByLUNID get QoS policy that associated LUN.
+// return ErrQoSPolicyNotFound if LUN has no QoS policy.
+func (d *Device) GetQoSPolicyByLUNID(ctx context.Context, lunID int) (*QoSPolicy, error) {
+	lun, err := d.GetLUN(ctx, lunID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LUN: %w", err)
+	}
+
+	policyID, err := strconv.Atoi(lun.IOCLASSID)
+	if err != nil {
+		return nil, ErrQoSPolicyNotFound
+	}
+
+	return d.GetQoSPolicy(ctx, policyID)
+}
+
+// CreateQoSPolicy create QoS policy that associated LUNs. policy is not activated.
+func (d *Device) CreateQoSPolicy(ctx context.Context, name string, spec QoSSpec, lunIDs []int) (*QoSPolicy, error) {
+	if name == "" || len(name) > MaxNameLength {
+		return nil, fmt.Errorf("name must be 1 to %d characters", MaxNameLength)
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate QoS spec: %w", err)
+	}
+
+	spath := "/ioclass"
+	param := spec.toParam(time.Now())
+	param.NAME = name
+	param.LUNLIST = toIDList(lunIDs)
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+
+	req, err := d.newRequest(ctx, "POST", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	policy := &QoSPolicy{}
+	if err = d.requestWithRetry(req, policy, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return policy, nil
+}
+
+// UpdateQoSPolicy change parameter of QoS policy. value of spec that is zero is not changed.
+func (d *Device) UpdateQoSPolicy(ctx context.Context, policyID int, spec QoSSpec) error {
+	if err := spec.Validate(); err != nil {
+		return fmt.Errorf("failed to validate QoS spec: %w", err)
+	}
+
+	spath := fmt.Sprintf("/ioclass/%d", policyID)
+	param := spec.toParam(time.Now())
+	param.ID = strconv.Itoa(policyID)
+	param.CLASSTYPE = ""
+	if spec.IOType == nil {
+		// keep current IO type
+		param.IOTYPE = ""
+	}
+	if spec.Schedule == nil {
+		// keep current schedule
+		param.SCHEDULEPOLICY, param.SCHEDULESTARTTIME, param.STARTTIME, param.DURATION = "", "", "", ""
+	}
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+
+	req, err := d.newRequest(ctx, "PUT", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// DeleteQoSPolicy delete QoS policy. policy is deactivated before delete.
+func (d *Device) DeleteQoSPolicy(ctx context.Context, policyID int) error {
+	policy, err := d.GetQoSPolicy(ctx, policyID)
+	if err != nil {
+		return fmt.Errorf("failed to get QoS policy: %w", err)
+	}
+	if policy.ENABLESTATUS == "true" {
+		if err := d.DeactivateQoSPolicy(ctx, policyID); err != nil {
+			return fmt.Errorf("failed to deactivate QoS policy: %w", err)
+		}
+	}
+
+	spath := fmt.Sprintf("/ioclass/%d", policyID)
+	req, err := d.newRequest(ctx, "DELETE", spath, nil)
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// ActivateQoSPolicy activate QoS policy
+func (d *Device) ActivateQoSPolicy(ctx context.Context, policyID int) error {
+	return d.switchQoSPolicy(ctx, policyID, true)
+}
+
+// DeactivateQoSPolicy deactivate QoS policy
+func (d *Device) DeactivateQoSPolicy(ctx context.Context, policyID int) error {
+	return d.switchQoSPolicy(ctx, policyID, false)
+}
+
+func (d *Device) switchQoSPolicy(ctx context.Context, policyID int, enable bool) error {
+	spath := fmt.Sprintf("/ioclass/active/%d", policyID)
+	param := struct {
+		ID           string `json:"ID"`
+		ENABLESTATUS string `json:"ENABLESTATUS"`
+	}{
+		ID:           strconv.Itoa(policyID),
+		ENABLESTATUS: strconv.FormatBool(enable),
+	}
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+
+	req, err := d.newRequest(ctx, "PUT", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// AddLUNToQoSPolicy associate LUN to QoS policy
+func (d *Device) AddLUNToQoSPolicy(ctx context.Context, policyID, lunID int) error {
+	return d.modifyQoSPolicyMembers(ctx, policyID, lunID, false, true)
+}
+
+// RemoveLUNFromQoSPolicy disassociate LUN from QoS policy
+func (d *Device) RemoveLUNFromQoSPolicy(ctx context.Context, policyID, lunID int) error {
+	return d.modifyQoSPolicyMembers(ctx, policyID, lunID, false, false)
+}
+
+// AddLunGroupToQoSPolicy associate LUN group to QoS policy
+func (d *Device) AddLunGroupToQoSPolicy(ctx context.Context, policyID, lungroupID int) error {
+	return d.modifyQoSPolicyMembers(ctx, policyID, lungroupID, true, true)
+}
+
+// RemoveLunGroupFromQoSPolicy disassociate LUN group from QoS policy
+func (d *Device) RemoveLunGroupFromQoSPolicy(ctx context.Context, policyID, lungroupID int) error {
+	return d.modifyQoSPolicyMembers(ctx, policyID, lungroupID, true, false)
+}
+
+// modifyQoSPolicyMembers add or remove member (LUN or LUN group) of QoS policy.
+// device replace all members by list, so get current members before update.
+func (d *Device) modifyQoSPolicyMembers(ctx context.Context, policyID, memberID int, isLunGroup, add bool) error {
+	policy, err := d.GetQoSPolicy(ctx, policyID)
+	if err != nil {
+		return fmt.Errorf("failed to get QoS policy: %w", err)
+	}
+
+	var current []int
+	if isLunGroup {
+		current, err = policy.LunGroupIDs()
+	} else {
+		current, err = policy.LUNIDs()
+	}
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, id := range current {
+		if id == memberID {
+			found = true
+		}
+	}
+	if add == found {
+		// already added or removed
+		return nil
+	}
+
+	var members []int
+	for _, id := range current {
+		if id != memberID {
+			members = append(members, id)
+		}
+	}
+	if add {
+		members = append(members, memberID)
+	}
+
+	list := toIDList(members)
+	if list == nil {
+		list = []string{}
+	}
+	param := struct {
+		ID           string    `json:"ID"`
+		TYPE         int       `json:"TYPE"`
+		LUNLIST      *[]string `json:"LUNLIST,omitempty"`
+		LUNGROUPLIST *[]string `json:"LUNGROUPLIST,omitempty"`
+	}{
+		ID:   strconv.Itoa(policyID),
+		TYPE: TypeQoSPolicy,
+	}
+	if isLunGroup {
+		param.LUNGROUPLIST = &list
+	} else {
+		param.LUNLIST = &list
+	}
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+
+	spath := fmt.Sprintf("/ioclass/%d", policyID)
+	req, err := d.newRequest(ctx, "PUT", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
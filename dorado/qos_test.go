@@ -0,0 +1,150 @@
+package dorado
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestQoSSpec_Validate(t *testing.T) {
+	tests := []struct {
+		spec  QoSSpec
+		valid bool
+	}{
+		{spec: QoSSpec{MaxIOPS: 1000, MaxBandwidth: 100}, valid: true},
+		{spec: QoSSpec{MinIOPS: 500, Latency: 1}, valid: true},
+		{spec: QoSSpec{}, valid: false},
+		{spec: QoSSpec{MaxIOPS: 1000, MinIOPS: 500}, valid: false},
+		{spec: QoSSpec{MaxIOPS: -1}, valid: false},
+		{spec: QoSSpec{MaxIOPS: 1000, Schedule: &QoSSchedule{Policy: QoSScheduleWeekly, Start: time.Now(), Duration: time.Hour}}, valid: false},
+		{spec: QoSSpec{MaxIOPS: 1000, Schedule: &QoSSchedule{Policy: QoSScheduleDaily, Start: time.Now(), Duration: 25 * time.Hour}}, valid: false},
+	}
+
+	for _, test := range tests {
+		err := test.spec.Validate()
+		if test.valid && err != nil {
+			t.Errorf("Validate(%+v) return err: %s", test.spec, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("Validate(%+v) must return err", test.spec)
+		}
+	}
+}
+
+func TestDevice_CreateQoSPolicy(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/ioclass", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var param map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
+			t.Fatalf("failed to decode request: %s", err)
+		}
+
+		want := map[string]interface{}{
+			"TYPE":              float64(TypeQoSPolicy),
+			"NAME":              "gold",
+			"LUNLIST":           []interface{}{"6", "7"},
+			"CLASSTYPE":         "1",
+			"IOTYPE":            "2",
+			"MAXIOPS":           "1000",
+			"SCHEDULEPOLICY":    "3",
+			"SCHEDULESTARTTIME": "1601510400",
+			"STARTTIME":         "22:00",
+			"DURATION":          "28800",
+			"CYCLESET":          "[0,6]",
+		}
+		if !reflect.DeepEqual(param, want) {
+			t.Errorf("request body %+v, want %+v", param, want)
+		}
+		fmt.Fprint(w, `{"data": {"ID": "1", "NAME": "gold", "ENABLESTATUS": "false", "LUNLIST": "[\"6\",\"7\"]", "TYPE": 230}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	policy, err := client.LocalDevice.CreateQoSPolicy(context.Background(), "gold", QoSSpec{
+		MaxIOPS: 1000,
+		Schedule: &QoSSchedule{
+			Policy:   QoSScheduleWeekly,
+			Start:    time.Date(2020, 10, 1, 22, 0, 0, 0, time.UTC),
+			Duration: 8 * time.Hour,
+			Weekdays: []time.Weekday{time.Sunday, time.Saturday},
+		},
+	}, []int{6, 7})
+	if err != nil {
+		t.Fatalf("CreateQoSPolicy return err: %s", err)
+	}
+
+	lunIDs, err := policy.LUNIDs()
+	if err != nil {
+		t.Fatalf("LUNIDs return err: %s", err)
+	}
+	if want := []int{6, 7}; !reflect.DeepEqual(lunIDs, want) {
+		t.Errorf("LUNIDs return %v, want %v", lunIDs, want)
+	}
+}
+
+func TestDevice_AddLunGroupToQoSPolicy(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/ioclass/1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"data": {"ID": "1", "LUNLIST": "[\"6\"]", "LUNGROUPLIST": "[\"3\"]", "TYPE": 230}, "error": {"code": 0, "description": "0"}}`)
+		case "PUT":
+			var param map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
+				t.Fatalf("failed to decode request: %s", err)
+			}
+			want := map[string]interface{}{
+				"ID":           "1",
+				"TYPE":         float64(TypeQoSPolicy),
+				"LUNGROUPLIST": []interface{}{"3", "4"},
+			}
+			if !reflect.DeepEqual(param, want) {
+				t.Errorf("request body %+v, want %+v", param, want)
+			}
+			fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+		default:
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+	})
+
+	if err := client.LocalDevice.AddLunGroupToQoSPolicy(context.Background(), 1, 4); err != nil {
+		t.Fatalf("AddLunGroupToQoSPolicy return err: %s", err)
+	}
+	// already associated, must not request PUT
+	if err := client.LocalDevice.AddLunGroupToQoSPolicy(context.Background(), 1, 3); err != nil {
+		t.Fatalf("AddLunGroupToQoSPolicy return err: %s", err)
+	}
+}
+
+func TestDevice_UpdateQoSPolicy_KeepIOType(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/ioclass/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		var param map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
+			t.Fatalf("failed to decode request: %s", err)
+		}
+		for _, key := range []string{"IOTYPE", "CLASSTYPE", "SCHEDULEPOLICY"} {
+			if _, ok := param[key]; ok {
+				t.Errorf("request must not have %s, but %v", key, param[key])
+			}
+		}
+		if param["MAXIOPS"] != "2000" {
+			t.Errorf("request MAXIOPS = %v, want 2000", param["MAXIOPS"])
+		}
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	if err := client.LocalDevice.UpdateQoSPolicy(context.Background(), 1, QoSSpec{MaxIOPS: 2000}); err != nil {
+		t.Fatalf("UpdateQoSPolicy return err: %s", err)
+	}
+}
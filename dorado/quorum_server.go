@@ -0,0 +1,282 @@
+package dorado
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// QuorumServer is arbitration server of HyperMetroDomain.
+// quorum server need to register both local device and remote device.
+type QuorumServer struct {
+	DESCRIPTION   string `json:"DESCRIPTION"`
+	HEALTHSTATUS  string `json:"HEALTHSTATUS"`
+	ID            string `json:"ID"`
+	NAME          string `json:"NAME"`
+	RUNNINGSTATUS string `json:"RUNNINGSTATUS"`
+	TYPE          int    `json:"TYPE"`
+}
+
+// QuorumServerLink is link between device and quorum server.
+type QuorumServerLink struct {
+	ADDRESS       string `json:"ADDRESS"`
+	HEALTHSTATUS  string `json:"HEALTHSTATUS"`
+	ID            string `json:"ID"`
+	LOCALPORTID   string `json:"LOCALPORTID"`
+	PARENTID      string `json:"PARENTID"`
+	PORT          int    `json:"PORT,string"`
+	RUNNINGSTATUS string `json:"RUNNINGSTATUS"`
+	TYPE          int    `json:"TYPE"`
+}
+
+// QuorumServerStatus is status of quorum server include links.
+type QuorumServerStatus struct {
+	Server QuorumServer
+	Links  []QuorumServerLink
+}
+
+// IsLinkUp return true if any link is up.
+func (s *QuorumServerStatus) IsLinkUp() bool {
+	if s == nil {
+		return false
+	}
+
+	for _, link := range s.Links {
+		if link.RUNNINGSTATUS == strconv.Itoa(StatusLinkUp) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetQuorumServers get quorum server objects query by SearchQuery.
+func (d *Device) GetQuorumServers(ctx context.Context, query *SearchQuery) ([]QuorumServer, error) {
+	spath := "/QuorumServer"
+
+	req, err := d.newRequest(ctx, "GET", spath, nil)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+	req = AddSearchQuery(req, query)
+
+	var quorumServers []QuorumServer
+	if err = d.requestWithRetry(req, &quorumServers, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	if len(quorumServers) == 0 {
+		return nil, ErrQuorumServerNotFound
+	}
+
+	return quorumServers, nil
+}
+
+// GetQuorumServer get quorum server object by id.
+func (d *Device) GetQuorumServer(ctx context.Context, quorumServerID string) (*QuorumServer, error) {
+	spath := fmt.Sprintf("/QuorumServer/%s", quorumServerID)
+
+	req, err := d.newRequest(ctx, "GET", spath, nil)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	quorumServer := &QuorumServer{}
+	if err = d.requestWithRetry(req, quorumServer, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return quorumServer, nil
+}
+
+// CreateQuorumServer create quorum server object.
+func (d *Device) CreateQuorumServer(ctx context.Context, name string) (*QuorumServer, error) {
+	spath := "/QuorumServer"
+	param := struct {
+		NAME string `json:"NAME"`
+	}{
+		NAME: name,
+	}
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+	req, err := d.newRequest(ctx, "POST", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	quorumServer := &QuorumServer{}
+	if err = d.requestWithRetry(req, quorumServer, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return quorumServer, nil
+}
+
+// DeleteQuorumServer delete quorum server object.
+// must be remove from HyperMetroDomain and delete all links before call this method.
+func (d *Device) DeleteQuorumServer(ctx context.Context, quorumServerID string) error {
+	spath := fmt.Sprintf("/QuorumServer/%s", quorumServerID)
+
+	req, err := d.newRequest(ctx, "DELETE", spath, nil)
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// GetQuorumServerLinks get links of quorum server.
+func (d *Device) GetQuorumServerLinks(ctx context.Context, quorumServerID string) ([]QuorumServerLink, error) {
+	spath := "/QuorumServerLink"
+
+	req, err := d.newRequest(ctx, "GET", spath, nil)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+	req = AddSearchQuery(req, &SearchQuery{
+		Filter: fmt.Sprintf("PARENTID::%s", quorumServerID),
+	})
+
+	var links []QuorumServerLink
+	if err = d.requestWithRetry(req, &links, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	if len(links) == 0 {
+		return nil, ErrQuorumServerLinkNotFound
+	}
+
+	return links, nil
+}
+
+// AddQuorumServerLink add link to quorum server.
+// localPortID is port of device that connect to quorum server, device choose if empty.
+func (d *Device) AddQuorumServerLink(ctx context.Context, quorumServerID, address string, port int, localPortID string) (*QuorumServerLink, error) {
+	spath := "/QuorumServerLink"
+	param := struct {
+		PARENTID    string `json:"PARENTID"`
+		ADDRESS     string `json:"ADDRESS"`
+		PORT        string `json:"PORT"`
+		LOCALPORTID string `json:"LOCALPORTID,omitempty"`
+	}{
+		PARENTID:    quorumServerID,
+		ADDRESS:     address,
+		PORT:        strconv.Itoa(port),
+		LOCALPORTID: localPortID,
+	}
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+	req, err := d.newRequest(ctx, "POST", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	link := &QuorumServerLink{}
+	if err = d.requestWithRetry(req, link, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return link, nil
+}
+
+// DeleteQuorumServerLink delete link of quorum server.
+func (d *Device) DeleteQuorumServerLink(ctx context.Context, linkID string) error {
+	spath := fmt.Sprintf("/QuorumServerLink/%s", linkID)
+
+	req, err := d.newRequest(ctx, "DELETE", spath, nil)
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// GetQuorumServerStatus get quorum server and links.
+func (d *Device) GetQuorumServerStatus(ctx context.Context, quorumServerID string) (*QuorumServerStatus, error) {
+	quorumServer, err := d.GetQuorumServer(ctx, quorumServerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quorum server: %w", err)
+	}
+
+	links, err := d.GetQuorumServerLinks(ctx, quorumServerID)
+	if err != nil && err != ErrQuorumServerLinkNotFound {
+		return nil, fmt.Errorf("failed to get quorum server links: %w", err)
+	}
+
+	return &QuorumServerStatus{
+		Server: *quorumServer,
+		Links:  links,
+	}, nil
+}
+
+// CreateQuorumServer create quorum server and link in local device and remote device.
+// return quorum servers in local device and remote device, IDs are different each device.
+func (c *Client) CreateQuorumServer(ctx context.Context, name, address string, port int) (*QuorumServer, *QuorumServer, error) {
+	if c.RemoteDevice == nil {
+		return nil, nil, errors.New("Remote IPs is required")
+	}
+
+	local, err := c.LocalDevice.createQuorumServerWithLink(ctx, name, address, port)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create quorum server in Local Device: %w", err)
+	}
+	remote, err := c.RemoteDevice.createQuorumServerWithLink(ctx, name, address, port)
+	if err != nil {
+		if err := c.LocalDevice.deleteQuorumServerWithLinks(ctx, local.ID); err != nil {
+			c.Logger.Printf("failed to delete quorum server in Local Device: %v", err)
+		}
+		return nil, nil, fmt.Errorf("failed to create quorum server in Remote Device: %w", err)
+	}
+
+	return local, remote, nil
+}
+
+func (d *Device) createQuorumServerWithLink(ctx context.Context, name, address string, port int) (*QuorumServer, error) {
+	quorumServer, err := d.CreateQuorumServer(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create quorum server: %w", err)
+	}
+
+	_, err = d.AddQuorumServerLink(ctx, quorumServer.ID, address, port, "")
+	if err != nil {
+		if err := d.DeleteQuorumServer(ctx, quorumServer.ID); err != nil {
+			d.Logger.Printf("failed to delete quorum server: %v", err)
+		}
+		return nil, fmt.Errorf("failed to add quorum server link: %w", err)
+	}
+
+	return quorumServer, nil
+}
+
+// deleteQuorumServerWithLinks delete links of quorum server and quorum server.
+func (d *Device) deleteQuorumServerWithLinks(ctx context.Context, quorumServerID string) error {
+	links, err := d.GetQuorumServerLinks(ctx, quorumServerID)
+	if err != nil && err != ErrQuorumServerLinkNotFound {
+		return fmt.Errorf("failed to get quorum server links: %w", err)
+	}
+	for _, link := range links {
+		if err := d.DeleteQuorumServerLink(ctx, link.ID); err != nil {
+			return fmt.Errorf("failed to delete quorum server link: %w", err)
+		}
+	}
+
+	return d.DeleteQuorumServer(ctx, quorumServerID)
+}
@@ -0,0 +1,89 @@
+package dorado
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDevice_GetHyperMetroDomainStatus(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/HyperMetroDomain/e4c2d1eaf02c0100", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"CPSID": "1", "CPSNAME": "qs01", "CPTYPE": "2", "ID": "e4c2d1eaf02c0100", "NAME": "domain01", "RUNNINGSTATUS": "1", "STANDBYCPSID": "", "TYPE": 15362}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/QuorumServer/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"HEALTHSTATUS": "1", "ID": "1", "NAME": "qs01", "RUNNINGSTATUS": "1", "TYPE": 15365}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/QuorumServerLink", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Query().Get("filter") != "PARENTID::1" {
+			t.Errorf("GetQuorumServerLinks query filter = %s", r.URL.Query().Get("filter"))
+		}
+		fmt.Fprint(w, `{"data": [{"ADDRESS": "192.0.2.1", "HEALTHSTATUS": "1", "ID": "10", "PARENTID": "1", "PORT": "30002", "RUNNINGSTATUS": "11", "TYPE": 15366}, {"ADDRESS": "192.0.2.2", "HEALTHSTATUS": "1", "ID": "11", "PARENTID": "1", "PORT": "30002", "RUNNINGSTATUS": "10", "TYPE": 15366}], "error": {"code": 0, "description": "0"}}`)
+	})
+
+	status, err := client.LocalDevice.GetHyperMetroDomainStatus(context.Background(), "e4c2d1eaf02c0100")
+	if err != nil {
+		t.Fatalf("GetHyperMetroDomainStatus return err: %s", err)
+	}
+
+	if status.Quorum == nil || len(status.Quorum.Links) != 2 || status.Quorum.Links[0].PORT != 30002 {
+		t.Errorf("GetHyperMetroDomainStatus return invalid quorum: %+v", status.Quorum)
+	}
+	if status.StandbyQuorum != nil {
+		t.Errorf("GetHyperMetroDomainStatus return standby quorum: %+v", status.StandbyQuorum)
+	}
+	if !status.IsQuorumReachable() {
+		t.Errorf("IsQuorumReachable return false, want true")
+	}
+}
+
+func TestClient_CreateQuorumServer_Cleanup(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	remoteMux, remoteTeardown := setupRemote(client)
+	defer remoteTeardown()
+
+	var deleted []string
+	mux.HandleFunc("/QuorumServer", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"data": {"ID": "1", "NAME": "qs01"}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/QuorumServerLink", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			fmt.Fprint(w, `{"data": {"ID": "10", "PARENTID": "1"}, "error": {"code": 0, "description": "0"}}`)
+		default:
+			fmt.Fprint(w, `{"data": [{"ID": "10", "PARENTID": "1"}], "error": {"code": 0, "description": "0"}}`)
+		}
+	})
+	mux.HandleFunc("/QuorumServerLink/10", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		deleted = append(deleted, r.URL.Path)
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/QuorumServer/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		deleted = append(deleted, r.URL.Path)
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	})
+	remoteMux.HandleFunc("/QuorumServer", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 1077949061, "description": "The name already exists."}}`)
+	})
+
+	if _, _, err := client.CreateQuorumServer(context.Background(), "qs01", "192.0.2.10", 30002); err == nil {
+		t.Fatalf("CreateQuorumServer must return err if failed in Remote Device")
+	}
+
+	want := []string{"/QuorumServerLink/10", "/QuorumServer/1"}
+	if !reflect.DeepEqual(deleted, want) {
+		t.Errorf("CreateQuorumServer deleted %v, want %v", deleted, want)
+	}
+}
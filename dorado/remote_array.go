@@ -0,0 +1,71 @@
+package dorado
+
+import (
+	"context"
+	"fmt"
+)
+
+// RemoteArray is remote device registered in device.
+// it is used by HyperMetroDomain and replication.
+type RemoteArray struct {
+	ARRAYTYPE     string `json:"ARRAYTYPE"`
+	HEALTHSTATUS  string `json:"HEALTHSTATUS"`
+	ID            string `json:"ID"`
+	NAME          string `json:"NAME"`
+	RUNNINGSTATUS string `json:"RUNNINGSTATUS"`
+	SN            string `json:"SN"`
+	TYPE          int    `json:"TYPE"`
+	WWN           string `json:"WWN"`
+}
+
+// ToHyperMetroDomainRemoteDevice convert to parameter of CreateHyperMetroDomain
+func (r *RemoteArray) ToHyperMetroDomainRemoteDevice() HyperMetroDomainRemoteDevice {
+	return HyperMetroDomainRemoteDevice{
+		DevID:   r.ID,
+		DevESN:  r.SN,
+		DevName: r.NAME,
+	}
+}
+
+// GetRemoteArrays get remote devices registered in device.
+func (d *Device) GetRemoteArrays(ctx context.Context, query *SearchQuery) ([]RemoteArray, error) {
+	spath := "/remote_device"
+
+	req, err := d.newRequest(ctx, "GET", spath, nil)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+	req = AddSearchQuery(req, query)
+
+	var remoteArrays []RemoteArray
+	if err = d.requestWithRetry(req, &remoteArrays, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	if len(remoteArrays) == 0 {
+		return nil, ErrRemoteArrayNotFound
+	}
+
+	return remoteArrays, nil
+}
+
+// GetRemoteArrayByDevice find remote device that is target device.
+func (d *Device) GetRemoteArrayByDevice(ctx context.Context, target *Device) (*RemoteArray, error) {
+	system, err := target.GetSystem(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system information of target device: %w", err)
+	}
+
+	remoteArrays, err := d.GetRemoteArrays(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get remote devices: %w", err)
+	}
+
+	for _, r := range remoteArrays {
+		if r.SN == system.ID {
+			return &r, nil
+		}
+	}
+
+	return nil, ErrRemoteArrayNotFound
+}
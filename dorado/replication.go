@@ -0,0 +1,296 @@
+package dorado
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// ReplicationClient is client for remote replication.
+// PrimaryDevice and SecondaryDevice are used as same as LocalDevice and RemoteDevice in Client.
+type ReplicationClient struct {
+	PrimaryDevice   *Device
+	SecondaryDevice *Device
+
+	Logger *log.Logger
+}
+
+// NewReplicationClient create ReplicationClient and set iBaseToken create by REST API.
+func NewReplicationClient(primaryIPs, secondaryIPs []string, username, password string, logger *log.Logger) (*ReplicationClient, error) {
+	client, err := NewReplicationClientDefaultToken(primaryIPs, secondaryIPs, username, password, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.SetToken()
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// NewReplicationClientDefaultToken create ReplicationClient.
+// this function not call REST API.
+func NewReplicationClientDefaultToken(primaryIPs, secondaryIPs []string, username, password string, logger *log.Logger) (*ReplicationClient, error) {
+	if len(secondaryIPs) == 0 {
+		return nil, errors.New("secondary IPs is required")
+	}
+
+	c, err := NewClientDefaultToken(primaryIPs, secondaryIPs, username, password, "", logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReplicationClient{
+		PrimaryDevice:   c.LocalDevice,
+		SecondaryDevice: c.RemoteDevice,
+		Logger:          c.Logger,
+	}, nil
+}
+
+// SetToken call REST API and set iBaseToken to client
+func (c *ReplicationClient) SetToken() error {
+	err := c.PrimaryDevice.setToken()
+	if err != nil {
+		return fmt.Errorf("failed to set token in primary device: %w", err)
+	}
+
+	err = c.SecondaryDevice.setToken()
+	if err != nil {
+		return fmt.Errorf("failed to set token in secondary device: %w", err)
+	}
+
+	return nil
+}
+
+// GetReplicationPair get replication pair by id
+func (c *ReplicationClient) GetReplicationPair(ctx context.Context, replicationPairID string) (*ReplicationPair, error) {
+	return c.PrimaryDevice.GetReplicationPair(ctx, replicationPairID)
+}
+
+// GetSecondaryRemoteArray get RemoteArray of secondary device that registered in primary device.
+func (c *ReplicationClient) GetSecondaryRemoteArray(ctx context.Context) (*RemoteArray, error) {
+	return c.PrimaryDevice.GetRemoteArrayByDevice(ctx, c.SecondaryDevice)
+}
+
+// CreateReplicatedVolume create LUNs in primary and secondary device and replication pair.
+// initial synchronization is started before return. LUNs and pair are deleted if failed.
+func (c *ReplicationClient) CreateReplicatedVolume(ctx context.Context, name uuid.UUID, capacityGB int, storagePoolName string, opts ReplicationOption) (*ReplicationPair, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate replication option: %w", err)
+	}
+
+	remoteArray, err := c.GetSecondaryRemoteArray(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secondary device in primary device: %w", err)
+	}
+
+	primaryLun, err := c.PrimaryDevice.CreateLUNWithSpec(ctx, name, capacityGB, storagePoolName, opts.LUNSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lun in primary device: %w", err)
+	}
+	secondaryLun, err := c.SecondaryDevice.CreateLUNWithSpec(ctx, name, capacityGB, storagePoolName, opts.LUNSpec)
+	if err != nil {
+		if err := c.PrimaryDevice.DeleteLUN(ctx, primaryLun.ID); err != nil {
+			c.Logger.Printf("failed to delete lun in primary device: %v", err)
+		}
+		return nil, fmt.Errorf("failed to create lun in secondary device: %w", err)
+	}
+
+	replicationPair, err := c.PrimaryDevice.CreateReplicationPair(ctx, primaryLun.ID, remoteArray.ID, secondaryLun.ID, opts)
+	if err != nil {
+		if err := c.PrimaryDevice.DeleteLUN(ctx, primaryLun.ID); err != nil {
+			c.Logger.Printf("failed to delete lun in primary device: %v", err)
+		}
+		if err := c.SecondaryDevice.DeleteLUN(ctx, secondaryLun.ID); err != nil {
+			c.Logger.Printf("failed to delete lun in secondary device: %v", err)
+		}
+		return nil, fmt.Errorf("failed to create replication pair: %w", err)
+	}
+
+	err = c.PrimaryDevice.SyncReplicationPair(ctx, replicationPair.ID)
+	if err != nil {
+		if err := c.DeleteReplicatedVolume(ctx, replicationPair.ID); err != nil {
+			c.Logger.Printf("failed to delete replicated volume: %v", err)
+		}
+		return nil, fmt.Errorf("failed to start initial sync: %w", err)
+	}
+
+	return replicationPair, nil
+}
+
+// DeleteReplicatedVolume delete replication pair and LUNs in primary and secondary device.
+func (c *ReplicationClient) DeleteReplicatedVolume(ctx context.Context, replicationPairID string) error {
+	rp, err := c.GetReplicationPair(ctx, replicationPairID)
+	if err != nil {
+		return fmt.Errorf("failed to get replication pair: %w", err)
+	}
+
+	if rp.ISINCG == "true" {
+		return fmt.Errorf("replication pair is in consistency group (ID: %s), please remove from group", rp.CGID)
+	}
+
+	if rp.RUNNINGSTATUS != strconv.Itoa(StatusReplicationSplit) {
+		err = c.PrimaryDevice.SplitReplicationPair(ctx, rp.ID)
+		if err != nil {
+			return fmt.Errorf("failed to split replication pair: %w", err)
+		}
+	}
+	err = c.PrimaryDevice.DeleteReplicationPair(ctx, rp.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete replication pair: %w", err)
+	}
+
+	err = c.PrimaryDevice.DeleteLUN(ctx, rp.LOCALRESID)
+	if err != nil {
+		return fmt.Errorf("failed to delete Primary LUN: %w", err)
+	}
+	err = c.SecondaryDevice.DeleteLUN(ctx, rp.REMOTERESID)
+	if err != nil {
+		return fmt.Errorf("failed to delete Secondary LUN: %w", err)
+	}
+
+	return nil
+}
+
+// SplitVolume split replication pair.
+func (c *ReplicationClient) SplitVolume(ctx context.Context, replicationPairID string) error {
+	return c.PrimaryDevice.SplitReplicationPair(ctx, replicationPairID)
+}
+
+// SyncVolume start to sync replication pair.
+func (c *ReplicationClient) SyncVolume(ctx context.Context, replicationPairID string) error {
+	return c.PrimaryDevice.SyncReplicationPair(ctx, replicationPairID)
+}
+
+// SwitchoverVolume switch primary and secondary of replication pair and re-sync.
+// After switchover, the device that was secondary has primary LUN of pair.
+func (c *ReplicationClient) SwitchoverVolume(ctx context.Context, replicationPairID string) error {
+	rp, err := c.GetReplicationPair(ctx, replicationPairID)
+	if err != nil {
+		return fmt.Errorf("failed to get replication pair: %w", err)
+	}
+	primary, secondary := c.roleDevices(rp.ISPRIMARY)
+
+	// async replication need to split before switch
+	if rp.REPLICATIONMODEL == strconv.Itoa(ReplicationModelAsync) && rp.RUNNINGSTATUS != strconv.Itoa(StatusReplicationSplit) {
+		err = primary.SplitReplicationPair(ctx, rp.ID)
+		if err != nil {
+			return fmt.Errorf("failed to split replication pair: %w", err)
+		}
+	}
+
+	err = primary.SwitchReplicationPair(ctx, rp.ID)
+	if err != nil {
+		return fmt.Errorf("failed to switch replication pair: %w", err)
+	}
+
+	err = secondary.SyncReplicationPair(ctx, rp.ID)
+	if err != nil {
+		return fmt.Errorf("failed to re-sync replication pair: %w", err)
+	}
+
+	return nil
+}
+
+// roleDevices return current primary and secondary device by ISPRIMARY that is got from PrimaryDevice.
+func (c *ReplicationClient) roleDevices(isPrimary string) (primary, secondary *Device) {
+	if isPrimary == "true" {
+		return c.PrimaryDevice, c.SecondaryDevice
+	}
+	return c.SecondaryDevice, c.PrimaryDevice
+}
+
+// CreateReplicationConsistencyGroup create replication consistency group in primary device.
+func (c *ReplicationClient) CreateReplicationConsistencyGroup(ctx context.Context, name string, opts ReplicationOption) (*ReplicationConsistencyGroup, error) {
+	return c.PrimaryDevice.CreateReplicationConsistencyGroup(ctx, name, opts)
+}
+
+// AddVolumesToConsistencyGroup add replication pairs to consistency group.
+// group is split while adding, and re-sync after added.
+func (c *ReplicationClient) AddVolumesToConsistencyGroup(ctx context.Context, cgID string, replicationPairIDs []string) error {
+	group, err := c.PrimaryDevice.GetReplicationConsistencyGroup(ctx, cgID)
+	if err != nil {
+		return fmt.Errorf("failed to get replication consistency group: %w", err)
+	}
+	if group.RUNNINGSTATUS != strconv.Itoa(StatusReplicationSplit) {
+		err = c.PrimaryDevice.SplitReplicationConsistencyGroup(ctx, cgID)
+		if err != nil {
+			return fmt.Errorf("failed to split replication consistency group: %w", err)
+		}
+	}
+
+	for _, id := range replicationPairIDs {
+		rp, err := c.GetReplicationPair(ctx, id)
+		if err != nil {
+			c.resyncConsistencyGroup(ctx, cgID)
+			return fmt.Errorf("failed to get replication pair: %w", err)
+		}
+		if rp.RUNNINGSTATUS != strconv.Itoa(StatusReplicationSplit) {
+			err = c.PrimaryDevice.SplitReplicationPair(ctx, id)
+			if err != nil {
+				c.resyncConsistencyGroup(ctx, cgID)
+				return fmt.Errorf("failed to split replication pair: %w", err)
+			}
+		}
+
+		err = c.PrimaryDevice.AddReplicationPairToConsistencyGroup(ctx, cgID, id)
+		if err != nil {
+			c.resyncConsistencyGroup(ctx, cgID, id)
+			return fmt.Errorf("failed to add replication pair to consistency group (ID: %s): %w", id, err)
+		}
+	}
+
+	err = c.PrimaryDevice.SyncReplicationConsistencyGroup(ctx, cgID)
+	if err != nil {
+		return fmt.Errorf("failed to sync replication consistency group: %w", err)
+	}
+
+	return nil
+}
+
+// resyncConsistencyGroup re-sync group and replication pairs that are split but not added to group.
+func (c *ReplicationClient) resyncConsistencyGroup(ctx context.Context, cgID string, replicationPairIDs ...string) {
+	if err := c.PrimaryDevice.SyncReplicationConsistencyGroup(ctx, cgID); err != nil {
+		c.Logger.Printf("failed to re-sync replication consistency group: %v", err)
+	}
+	for _, id := range replicationPairIDs {
+		if err := c.PrimaryDevice.SyncReplicationPair(ctx, id); err != nil {
+			c.Logger.Printf("failed to re-sync replication pair (ID: %s): %v", id, err)
+		}
+	}
+}
+
+// SwitchoverConsistencyGroup switch primary and secondary of replication consistency group and re-sync.
+// After switchover, the device that was secondary has primary LUNs of group.
+func (c *ReplicationClient) SwitchoverConsistencyGroup(ctx context.Context, cgID string) error {
+	group, err := c.PrimaryDevice.GetReplicationConsistencyGroup(ctx, cgID)
+	if err != nil {
+		return fmt.Errorf("failed to get replication consistency group: %w", err)
+	}
+	primary, secondary := c.roleDevices(group.ISPRIMARY)
+
+	if group.REPLICATIONMODEL == strconv.Itoa(ReplicationModelAsync) && group.RUNNINGSTATUS != strconv.Itoa(StatusReplicationSplit) {
+		err = primary.SplitReplicationConsistencyGroup(ctx, cgID)
+		if err != nil {
+			return fmt.Errorf("failed to split replication consistency group: %w", err)
+		}
+	}
+
+	err = primary.SwitchReplicationConsistencyGroup(ctx, cgID)
+	if err != nil {
+		return fmt.Errorf("failed to switch replication consistency group: %w", err)
+	}
+
+	err = secondary.SyncReplicationConsistencyGroup(ctx, cgID)
+	if err != nil {
+		return fmt.Errorf("failed to re-sync replication consistency group: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package dorado
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// ReplicationConsistencyGroup is consistency group of ReplicationPair.
+type ReplicationConsistencyGroup struct {
+	DESCRIPTION         string `json:"DESCRIPTION"`
+	HEALTHSTATUS        string `json:"HEALTHSTATUS"`
+	ID                  string `json:"ID"`
+	ISPRIMARY           string `json:"ISPRIMARY"`
+	NAME                string `json:"NAME"`
+	RECOVERYPOLICY      string `json:"RECOVERYPOLICY"`
+	REMOTEDEVICEID      string `json:"REMOTEDEVICEID"`
+	REMOTEDEVICENAME    string `json:"REMOTEDEVICENAME"`
+	REPLICATIONMODEL    string `json:"REPLICATIONMODEL"`
+	REPLICATIONPROGRESS string `json:"REPLICATIONPROGRESS"`
+	RUNNINGSTATUS       string `json:"RUNNINGSTATUS"`
+	SECRESACCESS        string `json:"SECRESACCESS"`
+	SPEED               string `json:"SPEED"`
+	SYNCHRONIZETYPE     string `json:"SYNCHRONIZETYPE"`
+	TIMINGVAL           string `json:"TIMINGVAL"`
+	TYPE                int    `json:"TYPE"`
+}
+
+// GetReplicationConsistencyGroups get replication consistency groups query by SearchQuery.
+func (d *Device) GetReplicationConsistencyGroups(ctx context.Context, query *SearchQuery) ([]ReplicationConsistencyGroup, error) {
+	spath := "/CONSISTENTGROUP"
+
+	req, err := d.newRequest(ctx, "GET", spath, nil)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+	req = AddSearchQuery(req, query)
+
+	var groups []ReplicationConsistencyGroup
+	if err = d.requestWithRetry(req, &groups, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	if len(groups) == 0 {
+		return nil, ErrReplicationConsistencyGroupNotFound
+	}
+
+	return groups, nil
+}
+
+// GetReplicationConsistencyGroup get replication consistency group by id.
+func (d *Device) GetReplicationConsistencyGroup(ctx context.Context, cgID string) (*ReplicationConsistencyGroup, error) {
+	spath := fmt.Sprintf("/CONSISTENTGROUP/%s", cgID)
+
+	req, err := d.newRequest(ctx, "GET", spath, nil)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	group := &ReplicationConsistencyGroup{}
+	if err = d.requestWithRetry(req, group, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return group, nil
+}
+
+// CreateReplicationConsistencyGroup create replication consistency group.
+// pairs in group must be same replication model as group.
+func (d *Device) CreateReplicationConsistencyGroup(ctx context.Context, name string, opts ReplicationOption) (*ReplicationConsistencyGroup, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate replication option: %w", err)
+	}
+
+	spath := "/CONSISTENTGROUP"
+	param := opts.toParam()
+	param.NAME = name
+	param.DESCRIPTION = name
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+	req, err := d.newRequest(ctx, "POST", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	group := &ReplicationConsistencyGroup{}
+	if err = d.requestWithRetry(req, group, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return group, nil
+}
+
+// UpdateReplicationConsistencyGroup update RPO, speed, schedule and recovery policy of replication consistency group.
+func (d *Device) UpdateReplicationConsistencyGroup(ctx context.Context, cgID string, opts ReplicationOption) error {
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("failed to validate replication option: %w", err)
+	}
+
+	spath := fmt.Sprintf("/CONSISTENTGROUP/%s", cgID)
+	param := opts.toParam()
+	param.ID = cgID
+	param.TYPE = strconv.Itoa(TypeReplicationConsistencyGroup)
+	param.REPLICATIONMODEL = ""
+
+	return d.putReplication(ctx, spath, param)
+}
+
+// DeleteReplicationConsistencyGroup delete replication consistency group.
+// must be remove all replication pairs from group before call this method.
+func (d *Device) DeleteReplicationConsistencyGroup(ctx context.Context, cgID string) error {
+	spath := fmt.Sprintf("/CONSISTENTGROUP/%s", cgID)
+
+	req, err := d.newRequest(ctx, "DELETE", spath, nil)
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// AddReplicationPairToConsistencyGroup add replication pair to consistency group.
+// group and pair must be split.
+func (d *Device) AddReplicationPairToConsistencyGroup(ctx context.Context, cgID, replicationPairID string) error {
+	return d.operateReplicationConsistencyGroupMember(ctx, "/ADD_MIRROR", cgID, replicationPairID)
+}
+
+// RemoveReplicationPairFromConsistencyGroup remove replication pair from consistency group.
+// group must be split.
+func (d *Device) RemoveReplicationPairFromConsistencyGroup(ctx context.Context, cgID, replicationPairID string) error {
+	return d.operateReplicationConsistencyGroupMember(ctx, "/DEL_MIRROR", cgID, replicationPairID)
+}
+
+func (d *Device) operateReplicationConsistencyGroupMember(ctx context.Context, spath, cgID, replicationPairID string) error {
+	param := struct {
+		ID     string `json:"ID"`
+		TYPE   string `json:"TYPE"`
+		RMLIST string `json:"RMLIST"`
+	}{
+		ID:     cgID,
+		TYPE:   strconv.Itoa(TypeReplicationConsistencyGroup),
+		RMLIST: replicationPairID,
+	}
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+
+	req, err := d.newRequest(ctx, "PUT", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// SplitReplicationConsistencyGroup split all pairs in group.
+func (d *Device) SplitReplicationConsistencyGroup(ctx context.Context, cgID string) error {
+	return d.operateReplication(ctx, "/SPLIT_CONSISTENCY_GROUP", cgID, TypeReplicationConsistencyGroup)
+}
+
+// SyncReplicationConsistencyGroup start to sync all pairs in group.
+func (d *Device) SyncReplicationConsistencyGroup(ctx context.Context, cgID string) error {
+	return d.operateReplication(ctx, "/SYNCHRONIZE_CONSISTENCY_GROUP", cgID, TypeReplicationConsistencyGroup)
+}
+
+// SwitchReplicationConsistencyGroup switch primary and secondary of group.
+func (d *Device) SwitchReplicationConsistencyGroup(ctx context.Context, cgID string) error {
+	return d.operateReplication(ctx, "/SWITCH_GROUP_ROLE", cgID, TypeReplicationConsistencyGroup)
+}
+
+// SetReplicationConsistencyGroupSecondaryAccess set access of secondary LUNs in group (SecondaryAccessReadOnly or SecondaryAccessReadWrite).
+// group must be split.
+func (d *Device) SetReplicationConsistencyGroupSecondaryAccess(ctx context.Context, cgID string, access int) error {
+	switch access {
+	case SecondaryAccessReadOnly:
+		return d.operateReplication(ctx, "/CONSISTENTGROUP/SET_SECODARY_WRITE_LOCK", cgID, TypeReplicationConsistencyGroup)
+	case SecondaryAccessReadWrite:
+		return d.operateReplication(ctx, "/CONSISTENTGROUP/CANCEL_SECODARY_WRITE_LOCK", cgID, TypeReplicationConsistencyGroup)
+	default:
+		return fmt.Errorf("invalid secondary access: %d", access)
+	}
+}
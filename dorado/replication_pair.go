@@ -0,0 +1,296 @@
+package dorado
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Range of replication RPO (TIMINGVAL)
+const (
+	MinReplicationRPOSecond = 3
+	MaxReplicationRPOSecond = 86400
+)
+
+// ReplicationPair is object of remote replication LUN
+type ReplicationPair struct {
+	CGID                string `json:"CGID"`
+	CGNAME              string `json:"CGNAME"`
+	HEALTHSTATUS        string `json:"HEALTHSTATUS"`
+	ID                  string `json:"ID"`
+	ISINCG              string `json:"ISINCG"`
+	ISPRIMARY           string `json:"ISPRIMARY"`
+	LOCALRESID          int    `json:"LOCALRESID,string"`
+	LOCALRESNAME        string `json:"LOCALRESNAME"`
+	LOCALRESTYPE        string `json:"LOCALRESTYPE"`
+	RECOVERYPOLICY      string `json:"RECOVERYPOLICY"`
+	REMOTEDEVICEID      string `json:"REMOTEDEVICEID"`
+	REMOTEDEVICENAME    string `json:"REMOTEDEVICENAME"`
+	REMOTEDEVICESN      string `json:"REMOTEDEVICESN"`
+	REMOTERESID         int    `json:"REMOTERESID,string"`
+	REMOTERESNAME       string `json:"REMOTERESNAME"`
+	REPLICATIONMODEL    string `json:"REPLICATIONMODEL"`
+	REPLICATIONPROGRESS string `json:"REPLICATIONPROGRESS"`
+	RUNNINGSTATUS       string `json:"RUNNINGSTATUS"`
+	SECRESACCESS        string `json:"SECRESACCESS"`
+	SECRESDATASTATUS    string `json:"SECRESDATASTATUS"`
+	SPEED               string `json:"SPEED"`
+	STARTTIME           string `json:"STARTTIME"`
+	SYNCHRONIZETYPE     string `json:"SYNCHRONIZETYPE"`
+	TIMINGVAL           string `json:"TIMINGVAL"`
+	TYPE                int    `json:"TYPE"`
+}
+
+// ReplicationOption is parameter of replication pair and replication consistency group
+type ReplicationOption struct {
+	// Model is ReplicationModelSync or ReplicationModelAsync.
+	Model int
+	// SynchronizeType and RPOSecond use only Model is ReplicationModelAsync.
+	SynchronizeType int
+	RPOSecond       int
+	// Speed is SpeedMedium if zero.
+	Speed int
+	// RecoveryPolicy is RecoveryPolicyAutomatic if zero.
+	RecoveryPolicy int
+	// LUNSpec is used by CreateReplicatedVolume, properties of new LUNs.
+	LUNSpec *LUNSpec
+}
+
+// Validate validate parameter
+func (o *ReplicationOption) Validate() error {
+	switch o.Model {
+	case ReplicationModelSync:
+		if o.SynchronizeType != 0 || o.RPOSecond != 0 {
+			return fmt.Errorf("synchronize type and RPO can't be set in sync replication")
+		}
+	case ReplicationModelAsync:
+		switch o.SynchronizeType {
+		case SynchronizeTypeManual:
+			if o.RPOSecond != 0 {
+				return fmt.Errorf("RPO can't be set in manual synchronize")
+			}
+		case SynchronizeTypeTimedAfterStart, SynchronizeTypeTimedAfterEnd:
+			if o.RPOSecond < MinReplicationRPOSecond || o.RPOSecond > MaxReplicationRPOSecond {
+				return fmt.Errorf("RPO must be between %d and %d seconds", MinReplicationRPOSecond, MaxReplicationRPOSecond)
+			}
+		default:
+			return fmt.Errorf("invalid synchronize type: %d", o.SynchronizeType)
+		}
+	default:
+		return fmt.Errorf("invalid replication model: %d", o.Model)
+	}
+
+	if o.Speed != 0 && (o.Speed < SpeedLow || o.Speed > SpeedHighest) {
+		return fmt.Errorf("invalid speed: %d", o.Speed)
+	}
+	if o.RecoveryPolicy != 0 && o.RecoveryPolicy != RecoveryPolicyAutomatic && o.RecoveryPolicy != RecoveryPolicyManual {
+		return fmt.Errorf("invalid recovery policy: %d", o.RecoveryPolicy)
+	}
+	if err := o.LUNSpec.Validate(); err != nil {
+		return fmt.Errorf("invalid LUN spec: %w", err)
+	}
+
+	return nil
+}
+
+type replicationParam struct {
+	ID               string `json:"ID,omitempty"`
+	TYPE             string `json:"TYPE,omitempty"`
+	NAME             string `json:"NAME,omitempty"`
+	DESCRIPTION      string `json:"DESCRIPTION,omitempty"`
+	LOCALRESID       string `json:"LOCALRESID,omitempty"`
+	LOCALRESTYPE     string `json:"LOCALRESTYPE,omitempty"`
+	REMOTEDEVICEID   string `json:"REMOTEDEVICEID,omitempty"`
+	REMOTERESID      string `json:"REMOTERESID,omitempty"`
+	REPLICATIONMODEL string `json:"REPLICATIONMODEL,omitempty"`
+	SYNCHRONIZETYPE  string `json:"SYNCHRONIZETYPE,omitempty"`
+	TIMINGVAL        string `json:"TIMINGVAL,omitempty"`
+	SPEED            string `json:"SPEED,omitempty"`
+	RECOVERYPOLICY   string `json:"RECOVERYPOLICY,omitempty"`
+}
+
+func (o *ReplicationOption) toParam() replicationParam {
+	speed := o.Speed
+	if speed == 0 {
+		speed = SpeedMedium
+	}
+	recoveryPolicy := o.RecoveryPolicy
+	if recoveryPolicy == 0 {
+		recoveryPolicy = RecoveryPolicyAutomatic
+	}
+
+	param := replicationParam{
+		REPLICATIONMODEL: strconv.Itoa(o.Model),
+		SPEED:            strconv.Itoa(speed),
+		RECOVERYPOLICY:   strconv.Itoa(recoveryPolicy),
+	}
+	if o.Model == ReplicationModelAsync {
+		param.SYNCHRONIZETYPE = strconv.Itoa(o.SynchronizeType)
+		if o.RPOSecond != 0 {
+			param.TIMINGVAL = strconv.Itoa(o.RPOSecond)
+		}
+	}
+
+	return param
+}
+
+// GetReplicationPairs get replication pair objects query by SearchQuery.
+func (d *Device) GetReplicationPairs(ctx context.Context, query *SearchQuery) ([]ReplicationPair, error) {
+	spath := "/REPLICATIONPAIR"
+
+	req, err := d.newRequest(ctx, "GET", spath, nil)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+	req = AddSearchQuery(req, query)
+
+	var replicationPairs []ReplicationPair
+	if err = d.requestWithRetry(req, &replicationPairs, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	if len(replicationPairs) == 0 {
+		return nil, ErrReplicationPairNotFound
+	}
+
+	return replicationPairs, nil
+}
+
+// GetReplicationPair get replication pair object by id.
+func (d *Device) GetReplicationPair(ctx context.Context, replicationPairID string) (*ReplicationPair, error) {
+	spath := fmt.Sprintf("/REPLICATIONPAIR/%s", replicationPairID)
+
+	req, err := d.newRequest(ctx, "GET", spath, nil)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	replicationPair := &ReplicationPair{}
+	if err = d.requestWithRetry(req, replicationPair, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return replicationPair, nil
+}
+
+// CreateReplicationPair create replication pair from local LUN to LUN in remote device.
+// remoteArrayID is ID of RemoteArray (not device ID).
+func (d *Device) CreateReplicationPair(ctx context.Context, localLUNID int, remoteArrayID string, remoteLUNID int, opts ReplicationOption) (*ReplicationPair, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate replication option: %w", err)
+	}
+
+	spath := "/REPLICATIONPAIR"
+	param := opts.toParam()
+	param.LOCALRESID = strconv.Itoa(localLUNID)
+	param.LOCALRESTYPE = strconv.Itoa(TypeLUN)
+	param.REMOTEDEVICEID = remoteArrayID
+	param.REMOTERESID = strconv.Itoa(remoteLUNID)
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+	req, err := d.newRequest(ctx, "POST", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	replicationPair := &ReplicationPair{}
+	if err = d.requestWithRetry(req, replicationPair, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return replicationPair, nil
+}
+
+// UpdateReplicationPair update RPO, speed, schedule and recovery policy of replication pair.
+// opts.Model must be same as current model, replication model can't be changed.
+func (d *Device) UpdateReplicationPair(ctx context.Context, replicationPairID string, opts ReplicationOption) error {
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("failed to validate replication option: %w", err)
+	}
+
+	spath := fmt.Sprintf("/REPLICATIONPAIR/%s", replicationPairID)
+	param := opts.toParam()
+	param.ID = replicationPairID
+	param.TYPE = strconv.Itoa(TypeReplicationPair)
+	param.REPLICATIONMODEL = ""
+
+	return d.putReplication(ctx, spath, param)
+}
+
+// DeleteReplicationPair delete replication pair.
+// must be split replication pair before call this method.
+func (d *Device) DeleteReplicationPair(ctx context.Context, replicationPairID string) error {
+	spath := fmt.Sprintf("/REPLICATIONPAIR/%s", replicationPairID)
+
+	req, err := d.newRequest(ctx, "DELETE", spath, nil)
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// SplitReplicationPair split replication pair.
+func (d *Device) SplitReplicationPair(ctx context.Context, replicationPairID string) error {
+	return d.operateReplication(ctx, "/REPLICATIONPAIR/split", replicationPairID, TypeReplicationPair)
+}
+
+// SyncReplicationPair start to sync replication pair.
+func (d *Device) SyncReplicationPair(ctx context.Context, replicationPairID string) error {
+	return d.operateReplication(ctx, "/REPLICATIONPAIR/sync", replicationPairID, TypeReplicationPair)
+}
+
+// SwitchReplicationPair switch primary and secondary of replication pair.
+func (d *Device) SwitchReplicationPair(ctx context.Context, replicationPairID string) error {
+	return d.operateReplication(ctx, "/REPLICATIONPAIR/switch", replicationPairID, TypeReplicationPair)
+}
+
+// SetReplicationPairSecondaryAccess set access of secondary LUN (SecondaryAccessReadOnly or SecondaryAccessReadWrite).
+// replication pair must be split.
+func (d *Device) SetReplicationPairSecondaryAccess(ctx context.Context, replicationPairID string, access int) error {
+	switch access {
+	case SecondaryAccessReadOnly:
+		return d.operateReplication(ctx, "/REPLICATIONPAIR/SET_SECODARY_WRITE_LOCK", replicationPairID, TypeReplicationPair)
+	case SecondaryAccessReadWrite:
+		return d.operateReplication(ctx, "/REPLICATIONPAIR/CANCEL_SECODARY_WRITE_LOCK", replicationPairID, TypeReplicationPair)
+	default:
+		return fmt.Errorf("invalid secondary access: %d", access)
+	}
+}
+
+func (d *Device) operateReplication(ctx context.Context, spath, id string, objType int) error {
+	param := replicationParam{
+		ID:   id,
+		TYPE: strconv.Itoa(objType),
+	}
+
+	return d.putReplication(ctx, spath, param)
+}
+
+func (d *Device) putReplication(ctx context.Context, spath string, param replicationParam) error {
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+
+	req, err := d.newRequest(ctx, "PUT", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,248 @@
+package dorado
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+func TestReplicationOption_Validate(t *testing.T) {
+	tests := []struct {
+		input ReplicationOption
+		err   bool
+	}{
+		{input: ReplicationOption{Model: ReplicationModelSync}, err: false},
+		{input: ReplicationOption{Model: ReplicationModelSync, RPOSecond: 60}, err: true},
+		{input: ReplicationOption{Model: ReplicationModelAsync, SynchronizeType: SynchronizeTypeTimedAfterEnd, RPOSecond: 60, Speed: SpeedHigh}, err: false},
+		{input: ReplicationOption{Model: ReplicationModelAsync, SynchronizeType: SynchronizeTypeTimedAfterEnd}, err: true},
+		{input: ReplicationOption{Model: ReplicationModelAsync, SynchronizeType: SynchronizeTypeManual}, err: false},
+		{input: ReplicationOption{Model: ReplicationModelAsync, SynchronizeType: SynchronizeTypeManual, Speed: 5}, err: true},
+		{input: ReplicationOption{}, err: true},
+	}
+
+	for _, test := range tests {
+		err := test.input.Validate()
+		if (err != nil) != test.err {
+			t.Errorf("Validate(%+v) return err: %v, want error: %t", test.input, err, test.err)
+		}
+	}
+}
+
+func TestDevice_GetReplicationPairs(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/REPLICATIONPAIR", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `
+{
+ "data": [
+        {
+            "CGID": "",
+            "CGNAME": "",
+            "HEALTHSTATUS": "1",
+            "ID": "a4c2d1eaf02c0001",
+            "ISINCG": "false",
+            "ISPRIMARY": "true",
+            "LOCALRESID": "216",
+            "LOCALRESNAME": "lun01",
+            "LOCALRESTYPE": "11",
+            "RECOVERYPOLICY": "1",
+            "REMOTEDEVICEID": "0",
+            "REMOTEDEVICENAME": "dr-site",
+            "REMOTEDEVICESN": "2102351234FSK1234567",
+            "REMOTERESID": "514",
+            "REMOTERESNAME": "lun01",
+            "REPLICATIONMODEL": "2",
+            "REPLICATIONPROGRESS": "100",
+            "RUNNINGSTATUS": "1",
+            "SECRESACCESS": "2",
+            "SECRESDATASTATUS": "2",
+            "SPEED": "2",
+            "STARTTIME": "1600000000",
+            "SYNCHRONIZETYPE": "3",
+            "TIMINGVAL": "60",
+            "TYPE": 263
+        }
+ ],
+ "error": {
+        "code": 0,
+        "description": "0"
+ }
+}`)
+	})
+
+	rps, err := client.LocalDevice.GetReplicationPairs(context.Background(), nil)
+	if err != nil {
+		t.Errorf("GetReplicationPairs return err: %s", err)
+	}
+
+	want := []ReplicationPair{
+		{
+			HEALTHSTATUS:        "1",
+			ID:                  "a4c2d1eaf02c0001",
+			ISINCG:              "false",
+			ISPRIMARY:           "true",
+			LOCALRESID:          216,
+			LOCALRESNAME:        "lun01",
+			LOCALRESTYPE:        "11",
+			RECOVERYPOLICY:      "1",
+			REMOTEDEVICEID:      "0",
+			REMOTEDEVICENAME:    "dr-site",
+			REMOTEDEVICESN:      "2102351234FSK1234567",
+			REMOTERESID:         514,
+			REMOTERESNAME:       "lun01",
+			REPLICATIONMODEL:    "2",
+			REPLICATIONPROGRESS: "100",
+			RUNNINGSTATUS:       "1",
+			SECRESACCESS:        "2",
+			SECRESDATASTATUS:    "2",
+			SPEED:               "2",
+			STARTTIME:           "1600000000",
+			SYNCHRONIZETYPE:     "3",
+			TIMINGVAL:           "60",
+			TYPE:                TypeReplicationPair,
+		},
+	}
+	if !reflect.DeepEqual(rps, want) {
+		t.Errorf("GetReplicationPairs return %+v, want %+v", rps, want)
+	}
+}
+
+func TestReplicationClient_SwitchoverVolume_SwitchedBack(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	remoteMux, remoteTeardown := setupRemote(client)
+	defer remoteTeardown()
+	c := &ReplicationClient{PrimaryDevice: client.LocalDevice, SecondaryDevice: client.RemoteDevice, Logger: client.Logger}
+
+	// pair is already switched, so SecondaryDevice has primary LUN
+	var calls []string
+	mux.HandleFunc("/REPLICATIONPAIR/4b6a4d1bf2b40000", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "4b6a4d1bf2b40000", "ISPRIMARY": "false", "REPLICATIONMODEL": "1", "RUNNINGSTATUS": "1", "TYPE": 263}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/REPLICATIONPAIR/", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "primary "+r.URL.Path)
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	})
+	remoteMux.HandleFunc("/REPLICATIONPAIR/", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "secondary "+r.URL.Path)
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	if err := c.SwitchoverVolume(context.Background(), "4b6a4d1bf2b40000"); err != nil {
+		t.Fatalf("SwitchoverVolume return err: %s", err)
+	}
+
+	want := []string{"secondary /REPLICATIONPAIR/switch", "primary /REPLICATIONPAIR/sync"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("SwitchoverVolume call %v, want %v", calls, want)
+	}
+}
+
+func TestReplicationClient_CreateReplicatedVolume_SyncFailed(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	remoteMux, remoteTeardown := setupRemote(client)
+	defer remoteTeardown()
+	c := &ReplicationClient{PrimaryDevice: client.LocalDevice, SecondaryDevice: client.RemoteDevice, Logger: client.Logger}
+
+	var calls []string
+	for name, m := range map[string]*http.ServeMux{"primary": mux, "secondary": remoteMux} {
+		name := name
+		m.HandleFunc("/storagepool", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"data": [{"ID": "1", "NAME": "pool"}], "error": {"code": 0, "description": "0"}}`)
+		})
+		m.HandleFunc("/lun", func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "POST")
+			fmt.Fprint(w, `{"data": {"ID": "11", "TYPE": 11}, "error": {"code": 0, "description": "0"}}`)
+		})
+		m.HandleFunc("/lun/11", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "DELETE" {
+				calls = append(calls, name+" "+r.Method+" "+r.URL.Path)
+			}
+			fmt.Fprint(w, `{"data": {"ID": "11", "TYPE": 11}, "error": {"code": 0, "description": "0"}}`)
+		})
+	}
+	remoteMux.HandleFunc("/system/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": {"ID": "2102351234"}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/remote_device", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": [{"ID": "0", "SN": "2102351234"}], "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/REPLICATIONPAIR", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"data": {"ID": "4b6a4d1bf2b40000", "TYPE": 263}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/REPLICATIONPAIR/sync", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 1077674272, "description": "sync failed"}}`)
+	})
+	mux.HandleFunc("/REPLICATIONPAIR/4b6a4d1bf2b40000", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			calls = append(calls, "primary "+r.Method+" "+r.URL.Path)
+		}
+		fmt.Fprint(w, `{"data": {"ID": "4b6a4d1bf2b40000", "ISINCG": "false", "LOCALRESID": "11", "REMOTERESID": "11", "RUNNINGSTATUS": "26", "TYPE": 263}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	_, err := c.CreateReplicatedVolume(context.Background(), uuid.NewV4(), 10, "pool", ReplicationOption{Model: ReplicationModelSync})
+	if err == nil {
+		t.Fatalf("CreateReplicatedVolume must return err if initial sync is failed")
+
+	}
+
+	want := []string{
+		"primary DELETE /REPLICATIONPAIR/4b6a4d1bf2b40000",
+		"primary DELETE /lun/11",
+		"secondary DELETE /lun/11",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("CreateReplicatedVolume call %v, want %v", calls, want)
+	}
+}
+
+func TestReplicationClient_AddVolumesToConsistencyGroup_AddFailed(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	c := &ReplicationClient{PrimaryDevice: client.LocalDevice, SecondaryDevice: client.RemoteDevice, Logger: client.Logger}
+
+	var calls []string
+	mux.HandleFunc("/CONSISTENTGROUP/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "1", "RUNNINGSTATUS": "1"}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/REPLICATIONPAIR/4b6a4d1bf2b40000", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "4b6a4d1bf2b40000", "RUNNINGSTATUS": "1", "TYPE": 263}, "error": {"code": 0, "description": "0"}}`)
+	})
+	for _, spath := range []string{"/SPLIT_CONSISTENCY_GROUP", "/SYNCHRONIZE_CONSISTENCY_GROUP", "/REPLICATIONPAIR/split", "/REPLICATIONPAIR/sync"} {
+		mux.HandleFunc(spath, func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, r.URL.Path)
+			fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+		})
+	}
+	mux.HandleFunc("/ADD_MIRROR", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.URL.Path)
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 1077674272, "description": "add failed"}}`)
+	})
+
+	err := c.AddVolumesToConsistencyGroup(context.Background(), "1", []string{"4b6a4d1bf2b40000"})
+	if err == nil {
+		t.Fatalf("AddVolumesToConsistencyGroup must return err if failed to add")
+	}
+
+	want := []string{
+		"/SPLIT_CONSISTENCY_GROUP",
+		"/REPLICATIONPAIR/split",
+		"/ADD_MIRROR",
+		"/SYNCHRONIZE_CONSISTENCY_GROUP",
+		"/REPLICATIONPAIR/sync",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("AddVolumesToConsistencyGroup call %v, want %v", calls, want)
+	}
+}
@@ -31,7 +31,6 @@ func (d *Device) getToken() (string, string, error) {
 		return "", "", fmt.Errorf("failed to json.Marshal: %w", err)
 	}
 	urlStr := d.URL.String()
-	d.HTTPClient.Jar = d.Jar
 	resp, err := d.HTTPClient.Post(urlStr+spath, "application/json", bytes.NewBuffer(jb))
 	if err != nil {
 		return "", "", fmt.Errorf("failed to get token request: %w", err)
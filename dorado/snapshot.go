@@ -13,6 +13,7 @@ import (
 
 // Snapshot is object of lun snapshot
 type Snapshot struct {
+	ASSOCIATEMETADATA     string `json:"ASSOCIATEMETADATA"`
 	CASCADEDLEVEL         string `json:"CASCADEDLEVEL"`
 	CASCADEDNUM           string `json:"CASCADEDNUM"`
 	CONSUMEDCAPACITY      string `json:"CONSUMEDCAPACITY"`
@@ -96,6 +97,21 @@ func (d *Device) GetSnapshot(ctx context.Context, snapshotID int) (*Snapshot, er
 
 // CreateSnapshot create object of snapshot
 func (d *Device) CreateSnapshot(ctx context.Context, lunID int, name uuid.UUID, description string) (*Snapshot, error) {
+	return d.CreateSnapshotByName(ctx, lunID, EncodeSnapshotName(name), description)
+}
+
+// CreateSnapshotByName create object of snapshot that named as it is.
+// name must be unique in device and less than MaxNameLength.
+func (d *Device) CreateSnapshotByName(ctx context.Context, lunID int, name, description string) (*Snapshot, error) {
+	return d.createSnapshot(ctx, TypeLUN, lunID, name, description)
+}
+
+// createSnapshot create object of snapshot of parent (LUN or snapshot)
+func (d *Device) createSnapshot(ctx context.Context, parentType, parentID int, name, description string) (*Snapshot, error) {
+	if len(name) == 0 || len(name) > MaxNameLength {
+		return nil, fmt.Errorf("snapshot name must be 1 to %d characters: %s", MaxNameLength, name)
+	}
+
 	spath := "/snapshot"
 	param := struct {
 		TYPE        string `json:"TYPE"`
@@ -105,9 +121,9 @@ func (d *Device) CreateSnapshot(ctx context.Context, lunID int, name uuid.UUID,
 		DESCRIPTION string `json:"DESCRIPTION"`
 	}{
 		TYPE:        strconv.Itoa(TypeSnapshot),
-		NAME:        EncodeSnapshotName(name),
-		PARENTTYPE:  strconv.Itoa(TypeLUN),
-		PARENTID:    strconv.Itoa(lunID),
+		NAME:        name,
+		PARENTTYPE:  strconv.Itoa(parentType),
+		PARENTID:    strconv.Itoa(parentID),
 		DESCRIPTION: description,
 	}
 	jb, err := json.Marshal(param)
@@ -130,7 +146,12 @@ func (d *Device) CreateSnapshot(ctx context.Context, lunID int, name uuid.UUID,
 
 // CreateSnapshotWithWait create snapshot and waiting ready
 func (d *Device) CreateSnapshotWithWait(ctx context.Context, lunID int, name uuid.UUID, description string) (*Snapshot, error) {
-	snapshot, err := d.CreateSnapshot(ctx, lunID, name, description)
+	return d.CreateSnapshotByNameWithWait(ctx, lunID, EncodeSnapshotName(name), description)
+}
+
+// CreateSnapshotByNameWithWait create snapshot that named as it is and waiting ready
+func (d *Device) CreateSnapshotByNameWithWait(ctx context.Context, lunID int, name, description string) (*Snapshot, error) {
+	snapshot, err := d.CreateSnapshotByName(ctx, lunID, name, description)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create snapshot: %w", err)
 	}
@@ -173,7 +194,16 @@ func (d *Device) snapshotIsReady(ctx context.Context, snapshotID int) (bool, err
 }
 
 // DeleteSnapshot delete snapshot
+// return ErrSnapshotMapped if snapshot is mapped to host, need to call DetachSnapshot before.
 func (d *Device) DeleteSnapshot(ctx context.Context, snapshotID int) error {
+	snapshot, err := d.GetSnapshot(ctx, snapshotID)
+	if err != nil {
+		return fmt.Errorf("failed to get snapshot: %w", err)
+	}
+	if snapshot.EXPOSEDTOINITIATOR == "true" {
+		return fmt.Errorf("can't delete snapshot (ID: %d): %w", snapshotID, ErrSnapshotMapped)
+	}
+
 	spath := fmt.Sprintf("/snapshot/%d", snapshotID)
 	param := struct {
 		TYPE string `json:"TYPE"`
@@ -200,6 +230,22 @@ func (d *Device) DeleteSnapshot(ctx context.Context, snapshotID int) error {
 	return nil
 }
 
+// StopAndDeleteSnapshot stop snapshot if active and delete it.
+// return ErrSnapshotMapped before stop if snapshot is mapped to host.
+func (d *Device) StopAndDeleteSnapshot(ctx context.Context, snapshot *Snapshot) error {
+	if snapshot.EXPOSEDTOINITIATOR == "true" {
+		return fmt.Errorf("can't delete snapshot (ID: %d): %w", snapshot.ID, ErrSnapshotMapped)
+	}
+
+	if snapshot.RUNNINGSTATUS == strconv.Itoa(StatusSnapshotActive) {
+		if err := d.StopSnapshot(ctx, snapshot.ID); err != nil {
+			return fmt.Errorf("failed to stop snapshot: %w", err)
+		}
+	}
+
+	return d.DeleteSnapshot(ctx, snapshot.ID)
+}
+
 // ActivateSnapshot activate snapshot
 func (d *Device) ActivateSnapshot(ctx context.Context, snapshotID int) error {
 	spath := "/snapshot/activate"
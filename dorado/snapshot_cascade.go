@@ -0,0 +1,96 @@
+package dorado
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// CascadedLevel return level of cascaded snapshot. snapshot of LUN is 0.
+func (s *Snapshot) CascadedLevel() int {
+	return parseIntOrUnknown(s.CASCADEDLEVEL)
+}
+
+// CascadedNum return number of snapshots that taken from this snapshot.
+func (s *Snapshot) CascadedNum() int {
+	return parseIntOrUnknown(s.CASCADEDNUM)
+}
+
+// CreateCascadedSnapshot create snapshot of snapshot (cascaded snapshot).
+func (d *Device) CreateCascadedSnapshot(ctx context.Context, parentSnapshotID int, name uuid.UUID, description string) (*Snapshot, error) {
+	return d.createSnapshot(ctx, TypeSnapshot, parentSnapshotID, EncodeSnapshotName(name), description)
+}
+
+// GetCascadedSnapshots get snapshots that taken from snapshot.
+func (d *Device) GetCascadedSnapshots(ctx context.Context, snapshotID int) ([]Snapshot, error) {
+	parent, err := d.GetSnapshot(ctx, snapshotID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshot: %w", err)
+	}
+	if parent.CascadedNum() == 0 {
+		return nil, ErrSnapshotNotFound
+	}
+
+	snapshots, err := d.GetSnapshots(ctx, &SearchQuery{
+		Filter: ToFilter("PARENTID", strconv.Itoa(snapshotID)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var cascaded []Snapshot
+	for _, snapshot := range snapshots {
+		if snapshot.PARENTTYPE == TypeSnapshot && snapshot.PARENTID == snapshotID {
+			cascaded = append(cascaded, snapshot)
+		}
+	}
+	if len(cascaded) == 0 {
+		return nil, ErrSnapshotNotFound
+	}
+
+	return cascaded, nil
+}
+
+// SnapshotTreeNode is node of snapshot tree
+type SnapshotTreeNode struct {
+	Snapshot Snapshot
+	Children []*SnapshotTreeNode
+}
+
+// GetSnapshotTree get all snapshots of LUN include cascaded snapshots as tree.
+// return snapshots of LUN (CASCADEDLEVEL is 0) as roots.
+func (d *Device) GetSnapshotTree(ctx context.Context, lunID int) ([]*SnapshotTreeNode, error) {
+	snapshots, err := d.GetSnapshots(ctx, &SearchQuery{
+		Filter: ToFilter("SOURCELUNID", strconv.Itoa(lunID)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// sort by level to create parent node before child
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].CascadedLevel() < snapshots[j].CascadedLevel()
+	})
+
+	var roots []*SnapshotTreeNode
+	nodes := map[int]*SnapshotTreeNode{}
+	for _, snapshot := range snapshots {
+		node := &SnapshotTreeNode{Snapshot: snapshot}
+		nodes[snapshot.ID] = node
+
+		if snapshot.PARENTTYPE != TypeSnapshot {
+			roots = append(roots, node)
+			continue
+		}
+		parent, ok := nodes[snapshot.PARENTID]
+		if !ok {
+			return nil, fmt.Errorf("parent snapshot (ID: %d) of snapshot (ID: %d) is not found", snapshot.PARENTID, snapshot.ID)
+		}
+		parent.Children = append(parent.Children, node)
+	}
+
+	return roots, nil
+}
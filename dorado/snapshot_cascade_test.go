@@ -0,0 +1,102 @@
+package dorado
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+func TestDevice_CreateCascadedSnapshot(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/snapshot", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var param map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
+			t.Fatalf("failed to decode request: %s", err)
+		}
+		if param["PARENTID"] != "12" || param["PARENTTYPE"] != "27" {
+			t.Errorf("CreateCascadedSnapshot parent = %s (type %s), want snapshot 12", param["PARENTID"], param["PARENTTYPE"])
+		}
+		fmt.Fprint(w, `{"data": {"ID": "15", "CASCADEDLEVEL": "1", "CASCADEDNUM": "0", "PARENTID": "12", "PARENTTYPE": 27, "TYPE": 27}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	snapshot, err := client.LocalDevice.CreateCascadedSnapshot(context.Background(), 12, uuid.UUID{}, "")
+	if err != nil {
+		t.Fatalf("CreateCascadedSnapshot return err: %s", err)
+	}
+	if snapshot.CascadedLevel() != 1 || snapshot.CascadedNum() != 0 {
+		t.Errorf("CreateCascadedSnapshot return level %d and num %d, want 1 and 0", snapshot.CascadedLevel(), snapshot.CascadedNum())
+	}
+}
+
+func TestDevice_GetCascadedSnapshots_NoChild(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/snapshot/15", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "15", "CASCADEDLEVEL": "1", "CASCADEDNUM": "0", "PARENTID": "12", "PARENTTYPE": 27, "TYPE": 27}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/snapshot", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("GetCascadedSnapshots must not list snapshots if CASCADEDNUM is 0")
+	})
+
+	_, err := client.LocalDevice.GetCascadedSnapshots(context.Background(), 15)
+	if err != ErrSnapshotNotFound {
+		t.Errorf("GetCascadedSnapshots return err: %v, want %v", err, ErrSnapshotNotFound)
+	}
+}
+
+func TestDevice_GetSnapshotTree(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/snapshot", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got := r.URL.Query().Get("filter"); got != "SOURCELUNID::7" {
+			t.Errorf("Request filter: %v, want %v", got, "SOURCELUNID::7")
+		}
+		// cascaded snapshot is listed before parent
+		fmt.Fprint(w, `{"data": [
+{"ID": "16", "CASCADEDLEVEL": "2", "CASCADEDNUM": "0", "PARENTID": "15", "PARENTTYPE": 27, "SOURCELUNID": "7", "TYPE": 27},
+{"ID": "15", "CASCADEDLEVEL": "1", "CASCADEDNUM": "1", "PARENTID": "12", "PARENTTYPE": 27, "SOURCELUNID": "7", "TYPE": 27},
+{"ID": "12", "CASCADEDLEVEL": "0", "CASCADEDNUM": "1", "PARENTID": "7", "PARENTTYPE": 11, "SOURCELUNID": "7", "TYPE": 27},
+{"ID": "13", "CASCADEDLEVEL": "0", "CASCADEDNUM": "0", "PARENTID": "7", "PARENTTYPE": 11, "SOURCELUNID": "7", "TYPE": 27}
+], "error": {"code": 0, "description": "0"}}`)
+	})
+
+	roots, err := client.LocalDevice.GetSnapshotTree(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("GetSnapshotTree return err: %s", err)
+	}
+
+	if len(roots) != 2 || roots[0].Snapshot.ID != 12 || roots[1].Snapshot.ID != 13 {
+		t.Fatalf("GetSnapshotTree return roots %+v, want snapshot 12 and 13", roots)
+	}
+	if len(roots[0].Children) != 1 || roots[0].Children[0].Snapshot.ID != 15 {
+		t.Fatalf("children of snapshot 12 is %+v, want snapshot 15", roots[0].Children)
+	}
+	if len(roots[0].Children[0].Children) != 1 || roots[0].Children[0].Children[0].Snapshot.ID != 16 {
+		t.Errorf("children of snapshot 15 is %+v, want snapshot 16", roots[0].Children[0].Children)
+	}
+}
+
+func TestDevice_CreateLUNFromSnapshot_NotActivated(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/snapshot/12", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "12", "RUNNINGSTATUS": "45", "USERCAPACITY": "2097152", "TYPE": 27}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	if _, err := client.LocalDevice.CreateLUNFromSnapshot(context.Background(), 12, uuid.UUID{}, 1, "pool"); err == nil {
+		t.Errorf("CreateLUNFromSnapshot must return err if snapshot is not activated")
+	}
+}
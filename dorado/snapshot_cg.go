@@ -0,0 +1,326 @@
+package dorado
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SnapshotConsistencyGroup is consistency group of snapshots.
+// snapshots of all LUNs in group are taken at same time (crash-consistent).
+type SnapshotConsistencyGroup struct {
+	DESCRIPTION       string `json:"DESCRIPTION"`
+	HEALTHSTATUS      string `json:"HEALTHSTATUS"`
+	ID                int    `json:"ID,string"`
+	NAME              string `json:"NAME"`
+	PARENTID          int    `json:"PARENTID,string"`
+	PARENTNAME        string `json:"PARENTNAME"`
+	PARENTTYPE        int    `json:"PARENTTYPE"`
+	ROLLBACKENDTIME   string `json:"ROLLBACKENDTIME"`
+	ROLLBACKRATE      string `json:"ROLLBACKRATE"`
+	ROLLBACKSPEED     string `json:"ROLLBACKSPEED"`
+	ROLLBACKSTARTTIME string `json:"ROLLBACKSTARTTIME"`
+	RUNNINGSTATUS     string `json:"RUNNINGSTATUS"`
+	TIMESTAMP         string `json:"TIMESTAMP"`
+	TYPE              int    `json:"TYPE"`
+}
+
+// GetRollbackProgress return RollbackProgress of snapshot consistency group.
+// SnapshotID is ID of group.
+func (g *SnapshotConsistencyGroup) GetRollbackProgress() RollbackProgress {
+	return RollbackProgress{
+		SnapshotID:  g.ID,
+		RollingBack: g.RUNNINGSTATUS == strconv.Itoa(StatusSnapshotRollingBack),
+		Progress:    parseIntOrUnknown(g.ROLLBACKRATE),
+		Speed:       parseIntOrUnknown(g.ROLLBACKSPEED),
+	}
+}
+
+// GetSnapshotConsistencyGroups get snapshot consistency groups by query
+func (d *Device) GetSnapshotConsistencyGroups(ctx context.Context, query *SearchQuery) ([]SnapshotConsistencyGroup, error) {
+	spath := "/SNAPSHOT_CONSISTENCY_GROUP"
+
+	req, err := d.newRequest(ctx, "GET", spath, nil)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+	req = AddSearchQuery(req, query)
+
+	var groups []SnapshotConsistencyGroup
+	if err = d.requestWithRetry(req, &groups, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	if len(groups) == 0 {
+		return nil, ErrSnapshotConsistencyGroupNotFound
+	}
+
+	return groups, nil
+}
+
+// GetSnapshotConsistencyGroup get snapshot consistency group by id
+func (d *Device) GetSnapshotConsistencyGroup(ctx context.Context, groupID int) (*SnapshotConsistencyGroup, error) {
+	spath := fmt.Sprintf("/SNAPSHOT_CONSISTENCY_GROUP/%d", groupID)
+
+	req, err := d.newRequest(ctx, "GET", spath, nil)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	group := &SnapshotConsistencyGroup{}
+	if err = d.requestWithRetry(req, group, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return group, nil
+}
+
+// GetSnapshotConsistencyGroupMembers get snapshots in snapshot consistency group
+func (d *Device) GetSnapshotConsistencyGroupMembers(ctx context.Context, groupID int) ([]Snapshot, error) {
+	return d.GetSnapshots(ctx, &SearchQuery{
+		Filter: ToFilter("snapCgId", strconv.Itoa(groupID)),
+	})
+}
+
+// CreateSnapshotConsistencyGroup create snapshot consistency group over LUNs.
+// snapshots in group are inactive, need to call ActivateSnapshotConsistencyGroup.
+func (d *Device) CreateSnapshotConsistencyGroup(ctx context.Context, name string, lunIDs []int) (*SnapshotConsistencyGroup, error) {
+	// snapshot consistency group is created from protection group that has LUNs
+	protectGroupID, err := d.createProtectGroup(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create protection group: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if err := d.deleteProtectGroup(ctx, protectGroupID); err != nil {
+				d.Logger.Printf("failed to delete protection group: %v\n", err)
+			}
+		}
+	}()
+	for _, lunID := range lunIDs {
+		if err = d.associateProtectGroup(ctx, protectGroupID, lunID); err != nil {
+			return nil, fmt.Errorf("failed to add LUN (ID: %d) to protection group: %w", lunID, err)
+		}
+	}
+
+	spath := "/SNAPSHOT_CONSISTENCY_GROUP"
+	param := struct {
+		NAME        string `json:"NAME"`
+		DESCRIPTION string `json:"DESCRIPTION"`
+		PARENTID    string `json:"PARENTID"`
+		PARENTTYPE  string `json:"PARENTTYPE"`
+	}{
+		NAME:        name,
+		DESCRIPTION: name,
+		PARENTID:    strconv.Itoa(protectGroupID),
+		PARENTTYPE:  strconv.Itoa(TypeProtectionGroup),
+	}
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+
+	req, err := d.newRequest(ctx, "POST", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	group := &SnapshotConsistencyGroup{}
+	if err = d.requestWithRetry(req, group, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return group, nil
+}
+
+// DeleteSnapshotConsistencyGroup stop and delete snapshot consistency group and snapshots in group.
+// protection group of snapshot consistency group is deleted too.
+func (d *Device) DeleteSnapshotConsistencyGroup(ctx context.Context, groupID int) error {
+	group, err := d.GetSnapshotConsistencyGroup(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("failed to get snapshot consistency group: %w", err)
+	}
+
+	if group.RUNNINGSTATUS == strconv.Itoa(StatusSnapshotActive) {
+		if err := d.StopSnapshotConsistencyGroup(ctx, groupID); err != nil {
+			return fmt.Errorf("failed to stop snapshot consistency group: %w", err)
+		}
+	}
+
+	spath := fmt.Sprintf("/SNAPSHOT_CONSISTENCY_GROUP/%d", groupID)
+	req, err := d.newRequest(ctx, "DELETE", spath, nil)
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	if group.PARENTTYPE == TypeProtectionGroup {
+		if err := d.deleteProtectGroup(ctx, group.PARENTID); err != nil {
+			return fmt.Errorf("failed to delete protection group: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ActivateSnapshotConsistencyGroup activate all snapshots in group at same time
+func (d *Device) ActivateSnapshotConsistencyGroup(ctx context.Context, groupID int) error {
+	return d.operateSnapshotConsistencyGroup(ctx, "/SNAPSHOT_CONSISTENCY_GROUP/activate", groupID, nil)
+}
+
+// StopSnapshotConsistencyGroup stop all snapshots in group
+func (d *Device) StopSnapshotConsistencyGroup(ctx context.Context, groupID int) error {
+	return d.operateSnapshotConsistencyGroup(ctx, "/SNAPSHOT_CONSISTENCY_GROUP/stop", groupID, nil)
+}
+
+// RollbackSnapshotConsistencyGroup start to rollback all source LUNs in group by speed (SpeedLow to SpeedHighest).
+func (d *Device) RollbackSnapshotConsistencyGroup(ctx context.Context, groupID, speed int) error {
+	if speed < SpeedLow || speed > SpeedHighest {
+		return fmt.Errorf("invalid speed: %d", speed)
+	}
+
+	return d.operateSnapshotConsistencyGroup(ctx, "/SNAPSHOT_CONSISTENCY_GROUP/rollback", groupID, &speed)
+}
+
+// RollbackSnapshotConsistencyGroupWithWait start to rollback all source LUNs in group and wait to be done.
+func (d *Device) RollbackSnapshotConsistencyGroupWithWait(ctx context.Context, groupID, speed int, opts *WaitRollbackOption) error {
+	if err := d.RollbackSnapshotConsistencyGroup(ctx, groupID, speed); err != nil {
+		return fmt.Errorf("failed to rollback snapshot consistency group: %w", err)
+	}
+
+	timeout := time.After(opts.timeout())
+	ticker := time.NewTicker(opts.interval())
+	defer ticker.Stop()
+
+	for {
+		group, err := d.GetSnapshotConsistencyGroup(ctx, groupID)
+		if err != nil {
+			return fmt.Errorf("failed to get snapshot consistency group (ID: %d): %w", groupID, err)
+		}
+		progress := group.GetRollbackProgress()
+		opts.report(progress)
+
+		if group.HEALTHSTATUS != strconv.Itoa(StatusHealth) {
+			return fmt.Errorf("snapshot consistency group health status is bad (HEALTHSTATUS: %s)", group.HEALTHSTATUS)
+		}
+		if !progress.RollingBack {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			return ErrTimeoutWait
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Device) operateSnapshotConsistencyGroup(ctx context.Context, spath string, groupID int, speed *int) error {
+	param := struct {
+		ID            string `json:"ID"`
+		ROLLBACKSPEED string `json:"ROLLBACKSPEED,omitempty"`
+	}{
+		ID: strconv.Itoa(groupID),
+	}
+	if speed != nil {
+		param.ROLLBACKSPEED = strconv.Itoa(*speed)
+	}
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+
+	req, err := d.newRequest(ctx, "PUT", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// createProtectGroup create protection group, return ID of protection group
+func (d *Device) createProtectGroup(ctx context.Context, name string) (int, error) {
+	spath := "/protectgroup"
+	param := struct {
+		PROTECTGROUPNAME string `json:"protectGroupName"`
+		DESCRIPTION      string `json:"description"`
+	}{
+		PROTECTGROUPNAME: name,
+		DESCRIPTION:      name,
+	}
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return 0, fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+
+	req, err := d.newRequest(ctx, "POST", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return 0, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	protectGroup := struct {
+		ID int `json:"protectGroupId,string"`
+	}{}
+	if err = d.requestWithRetry(req, &protectGroup, DefaultHTTPRetryCount); err != nil {
+		return 0, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return protectGroup.ID, nil
+}
+
+// associateProtectGroup add LUN to protection group
+func (d *Device) associateProtectGroup(ctx context.Context, protectGroupID, lunID int) error {
+	spath := "/protectgroup/associate"
+	param := AssociateParam{
+		ID:               strconv.Itoa(protectGroupID),
+		ASSOCIATEOBJID:   strconv.Itoa(lunID),
+		ASSOCIATEOBJTYPE: TypeLUN,
+	}
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+
+	req, err := d.newRequest(ctx, "POST", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// deleteProtectGroup delete protection group
+func (d *Device) deleteProtectGroup(ctx context.Context, protectGroupID int) error {
+	spath := fmt.Sprintf("/protectgroup/%d", protectGroupID)
+
+	req, err := d.newRequest(ctx, "DELETE", spath, nil)
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package dorado
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDevice_CreateSnapshotConsistencyGroup(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/protectgroup", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"data": {"protectGroupId": "3", "protectGroupName": "db-group"}, "error": {"code": 0, "description": "0"}}`)
+	})
+	var associated []string
+	mux.HandleFunc("/protectgroup/associate", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var param AssociateParam
+		if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
+			t.Fatalf("failed to decode request: %s", err)
+		}
+		if param.ID != "3" {
+			t.Errorf("associate protection group ID = %s, want 3", param.ID)
+		}
+		associated = append(associated, param.ASSOCIATEOBJID)
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/SNAPSHOT_CONSISTENCY_GROUP", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var param map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
+			t.Fatalf("failed to decode request: %s", err)
+		}
+		if param["PARENTID"] != "3" || param["PARENTTYPE"] != "57615" {
+			t.Errorf("CreateSnapshotConsistencyGroup parent = %s (type %s), want protection group 3", param["PARENTID"], param["PARENTTYPE"])
+		}
+		fmt.Fprint(w, `{"data": {"ID": "8", "NAME": "db-group", "PARENTID": "3", "PARENTTYPE": 57615, "HEALTHSTATUS": "1", "RUNNINGSTATUS": "45", "TYPE": 57646}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	group, err := client.LocalDevice.CreateSnapshotConsistencyGroup(context.Background(), "db-group", []int{10, 11})
+	if err != nil {
+		t.Fatalf("CreateSnapshotConsistencyGroup return err: %s", err)
+	}
+
+	want := &SnapshotConsistencyGroup{
+		HEALTHSTATUS:  "1",
+		ID:            8,
+		NAME:          "db-group",
+		PARENTID:      3,
+		PARENTTYPE:    TypeProtectionGroup,
+		RUNNINGSTATUS: "45",
+		TYPE:          TypeSnapshotConsistencyGroup,
+	}
+	if !reflect.DeepEqual(group, want) {
+		t.Errorf("CreateSnapshotConsistencyGroup return %+v, want %+v", group, want)
+	}
+	if !reflect.DeepEqual(associated, []string{"10", "11"}) {
+		t.Errorf("CreateSnapshotConsistencyGroup associate LUNs %v, want [10 11]", associated)
+	}
+}
+
+func TestDevice_DeleteSnapshotConsistencyGroup(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var called []string
+	mux.HandleFunc("/SNAPSHOT_CONSISTENCY_GROUP/8", func(w http.ResponseWriter, r *http.Request) {
+		called = append(called, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"data": {"ID": "8", "NAME": "db-group", "PARENTID": "3", "PARENTTYPE": 57615, "HEALTHSTATUS": "1", "RUNNINGSTATUS": "43", "TYPE": 57646}, "error": {"code": 0, "description": "0"}}`)
+		case "DELETE":
+			fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+		}
+	})
+	mux.HandleFunc("/SNAPSHOT_CONSISTENCY_GROUP/stop", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		called = append(called, r.Method+" "+r.URL.Path)
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/protectgroup/3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		called = append(called, r.Method+" "+r.URL.Path)
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	err := client.LocalDevice.DeleteSnapshotConsistencyGroup(context.Background(), 8)
+	if err != nil {
+		t.Fatalf("DeleteSnapshotConsistencyGroup return err: %s", err)
+	}
+
+	want := []string{
+		"GET /SNAPSHOT_CONSISTENCY_GROUP/8",
+		"PUT /SNAPSHOT_CONSISTENCY_GROUP/stop",
+		"DELETE /SNAPSHOT_CONSISTENCY_GROUP/8",
+		"DELETE /protectgroup/3",
+	}
+	if !reflect.DeepEqual(called, want) {
+		t.Errorf("DeleteSnapshotConsistencyGroup call %v, want %v", called, want)
+	}
+}
+
+func TestVolumeSnapshotGroup_snapshotOf(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/snapshot", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got := r.URL.Query().Get("filter"); got != "snapCgId::8" {
+			t.Errorf("Request filter: %v, want %v", got, "snapCgId::8")
+		}
+		fmt.Fprint(w, `{"data": [{"ID": "20", "SOURCELUNID": "10", "snapCgId": "8", "TYPE": 27}, {"ID": "21", "SOURCELUNID": "11", "snapCgId": "8", "TYPE": 27}], "error": {"code": 0, "description": "0"}}`)
+	})
+
+	vsg := &VolumeSnapshotGroup{Local: &SnapshotConsistencyGroup{ID: 8}}
+	snapshot, err := vsg.snapshotOf(context.Background(), client.LocalDevice, SideLocal, 11)
+	if err != nil {
+		t.Fatalf("snapshotOf return err: %s", err)
+	}
+	if snapshot.ID != 21 {
+		t.Errorf("snapshotOf return snapshot (ID: %d), want 21", snapshot.ID)
+	}
+
+	if _, err := vsg.snapshotOf(context.Background(), client.RemoteDevice, SideRemote, 11); err == nil {
+		t.Errorf("snapshotOf must return err if group is not set")
+	}
+}
@@ -0,0 +1,180 @@
+package dorado
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// AttachSnapshot create mapping of snapshot to host for backup or file-level restore.
+// snapshot is added to lungroup of hostname, use hostname of backup agent to create dedicated mapping view for backup.
+// snapshot must be activated. snapshot is mapped read/write, host must mount it read-only to keep snapshot data.
+func (d *Device) AttachSnapshot(ctx context.Context, portgroupName, hostname, iqn string, snapshotID int, opts *AttachVolumeOption) (*ConnectionInfo, error) {
+	if opts == nil {
+		opts = &AttachVolumeOption{}
+	}
+	if opts.HostLUNID != nil {
+		return nil, fmt.Errorf("HostLUNID is not supported for snapshot")
+	}
+
+	snapshot, err := d.GetSnapshot(ctx, snapshotID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshot: %w", err)
+	}
+	if snapshot.RUNNINGSTATUS != strconv.Itoa(StatusSnapshotActive) {
+		return nil, fmt.Errorf("snapshot is not activated (RUNNINGSTATUS: %s)", snapshot.RUNNINGSTATUS)
+	}
+
+	attachment, err := d.prepareAttach(ctx, portgroupName, hostname, []string{iqn}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.AssociateSnapshot(ctx, attachment.LunGroup.ID, snapshotID); err != nil {
+		return nil, fmt.Errorf("failed to associate snapshot to lungroup: %w", err)
+	}
+
+	targetIQNs, err := d.GetTargetIQNs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target IQNs: %w", err)
+	}
+	portalIPs, err := d.GetPortalIPAddresses(ctx, attachment.PortGroup.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portal IP addresses: %w", err)
+	}
+	hostLUNIDs, err := d.GetHostSnapshotLUNIDs(ctx, attachment.Host.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get host LUN IDs: %w", err)
+	}
+	hostLUNID, ok := hostLUNIDs[snapshotID]
+	if !ok {
+		return nil, fmt.Errorf("snapshot (ID: %d) is not associated host (ID: %d)", snapshotID, attachment.Host.ID)
+	}
+
+	return &ConnectionInfo{
+		TargetIQNs: targetIQNs,
+		PortalIPs:  portalIPs,
+		HostLUNID:  hostLUNID,
+		CHAP:       opts.CHAP,
+	}, nil
+}
+
+// DetachSnapshot delete mapping of snapshot from host
+func (d *Device) DetachSnapshot(ctx context.Context, snapshotID int) error {
+	lungroup, err := d.GetLunGroupBySnapshotID(ctx, snapshotID)
+	if err != nil {
+		return fmt.Errorf("failed to get lungroup: %w", err)
+	}
+
+	err = d.DisAssociateSnapshot(ctx, lungroup.ID, snapshotID)
+	if err != nil {
+		return fmt.Errorf("failed to disassociate snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// AssociateSnapshot associate snapshot to lun group
+func (d *Device) AssociateSnapshot(ctx context.Context, lungroupID, snapshotID int) error {
+	spath := "/lungroup/associate"
+	param := AssociateParam{
+		ID:               strconv.Itoa(lungroupID),
+		ASSOCIATEOBJID:   strconv.Itoa(snapshotID),
+		ASSOCIATEOBJTYPE: TypeSnapshot,
+	}
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+
+	req, err := d.newRequest(ctx, "POST", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// DisAssociateSnapshot dis associate snapshot from lun group
+func (d *Device) DisAssociateSnapshot(ctx context.Context, lungroupID, snapshotID int) error {
+	spath := "/lungroup/associate"
+	param := &AssociateParam{
+		ID:               strconv.Itoa(lungroupID),
+		ASSOCIATEOBJID:   strconv.Itoa(snapshotID),
+		ASSOCIATEOBJTYPE: TypeSnapshot,
+	}
+
+	req, err := d.newRequest(ctx, "DELETE", spath, nil)
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+	req = AddAssociateParam(req, param)
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// GetLunGroupBySnapshotID get associated lun group by snapshot id.
+func (d *Device) GetLunGroupBySnapshotID(ctx context.Context, snapshotID int) (*LunGroup, error) {
+	query := &SearchQuery{
+		AssociateObjType: strconv.Itoa(TypeSnapshot),
+		AssociateObjID:   strconv.Itoa(snapshotID),
+		Type:             strconv.Itoa(TypeLUNGroup),
+	}
+
+	lungroups, err := d.GetAssociateLunGroups(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lun group: %w", err)
+	}
+	if len(lungroups) == 0 {
+		return nil, ErrLunGroupNotFound
+	}
+	if len(lungroups) != 1 {
+		return nil, fmt.Errorf("found multiple LUN Group in same snapshot id")
+	}
+
+	return &lungroups[0], nil
+}
+
+// GetHostSnapshotLUNIDs get LUN ID per host of all snapshots that associated host.
+// return map of snapshot ID to host LUN ID.
+func (d *Device) GetHostSnapshotLUNIDs(ctx context.Context, hostID int) (map[int]int, error) {
+	spath := "/snapshot/associate"
+
+	req, err := d.newRequest(ctx, "GET", spath, nil)
+	if err != nil {
+		return nil, fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+	req = AddSearchQuery(req, &SearchQuery{
+		AssociateObjType: strconv.Itoa(TypeHost),
+		AssociateObjID:   strconv.Itoa(hostID),
+	})
+
+	var snapshots []Snapshot
+	if err = d.requestWithRetry(req, &snapshots, DefaultHTTPRetryCount); err != nil {
+		return nil, fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	hostLUNIDs := map[int]int{}
+	for _, snapshot := range snapshots {
+		hostLunID := AssociateMetaData{}
+		if err := json.Unmarshal([]byte(snapshot.ASSOCIATEMETADATA), &hostLunID); err != nil {
+			return nil, fmt.Errorf("failed to parse ASSOCIATEMETADATA: %w", err)
+		}
+
+		hostLUNIDs[snapshot.ID] = hostLunID.HostLUNID
+	}
+
+	return hostLUNIDs, nil
+}
@@ -0,0 +1,92 @@
+package dorado
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDevice_GetHostSnapshotLUNIDs(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/snapshot/associate", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got := r.URL.Query().Get("ASSOCIATEOBJID"); got != "5" {
+			t.Errorf("Request ASSOCIATEOBJID: %v, want %v", got, "5")
+		}
+		fmt.Fprint(w, `{"data": [{"ID": "12", "ASSOCIATEMETADATA": "{\"HostLUNID\":3}", "TYPE": 27}], "error": {"code": 0, "description": "0"}}`)
+	})
+
+	hostLUNIDs, err := client.LocalDevice.GetHostSnapshotLUNIDs(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("GetHostSnapshotLUNIDs return err: %s", err)
+	}
+
+	want := map[int]int{12: 3}
+	if !reflect.DeepEqual(hostLUNIDs, want) {
+		t.Errorf("GetHostSnapshotLUNIDs return %+v, want %+v", hostLUNIDs, want)
+	}
+}
+
+func TestDevice_DetachSnapshot(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/lungroup/associate", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			if got := r.URL.Query().Get("ASSOCIATEOBJTYPE"); got != "27" {
+				t.Errorf("Request ASSOCIATEOBJTYPE: %v, want %v", got, "27")
+			}
+			fmt.Fprint(w, `{"data": [{"ID": "7", "NAME": "backup01", "TYPE": 256}], "error": {"code": 0, "description": "0"}}`)
+		case "DELETE":
+			if got := r.URL.Query().Get("ID"); got != "7" {
+				t.Errorf("Request ID: %v, want %v", got, "7")
+			}
+			if got := r.URL.Query().Get("ASSOCIATEOBJID"); got != "12" {
+				t.Errorf("Request ASSOCIATEOBJID: %v, want %v", got, "12")
+			}
+			fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+		default:
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+	})
+
+	if err := client.LocalDevice.DetachSnapshot(context.Background(), 12); err != nil {
+		t.Errorf("DetachSnapshot return err: %s", err)
+	}
+}
+
+func TestDevice_DeleteSnapshot_Mapped(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/snapshot/12", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "12", "EXPOSEDTOINITIATOR": "true", "RUNNINGSTATUS": "43", "TYPE": 27}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	err := client.LocalDevice.DeleteSnapshot(context.Background(), 12)
+	if !errors.Is(err, ErrSnapshotMapped) {
+		t.Errorf("DeleteSnapshot return err: %v, want %v", err, ErrSnapshotMapped)
+	}
+}
+
+func TestDevice_StopAndDeleteSnapshot_Mapped(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/snapshot/stop", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("StopAndDeleteSnapshot must not stop mapped snapshot")
+	})
+
+	snapshot := &Snapshot{ID: 12, EXPOSEDTOINITIATOR: "true", RUNNINGSTATUS: "43"}
+	err := client.LocalDevice.StopAndDeleteSnapshot(context.Background(), snapshot)
+	if !errors.Is(err, ErrSnapshotMapped) {
+		t.Errorf("StopAndDeleteSnapshot return err: %v, want %v", err, ErrSnapshotMapped)
+	}
+}
@@ -0,0 +1,242 @@
+package dorado
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// Default values of WaitSnapshotRollback
+var (
+	DefaultRollbackTimeout  = 30 * time.Minute
+	DefaultRollbackInterval = 5 * time.Second
+)
+
+// RollbackProgress is progress of snapshot rollback
+type RollbackProgress struct {
+	SnapshotID  int
+	RollingBack bool
+	Progress    int // percent, -1 is unknown
+	Speed       int // -1 is unknown
+}
+
+// WaitRollbackOption is optional parameter of WaitSnapshotRollback
+type WaitRollbackOption struct {
+	// Timeout is DefaultRollbackTimeout if zero.
+	Timeout time.Duration
+	// Interval is DefaultRollbackInterval if zero.
+	Interval time.Duration
+	// ProgressFunc is called per polling if set.
+	ProgressFunc func(RollbackProgress)
+
+	// WaitSynced wait that HyperMetroPair is synchronized after rollback if set.
+	// used by RollbackVolumeSnapshot only.
+	WaitSynced *WaitSyncOption
+}
+
+func (o *WaitRollbackOption) timeout() time.Duration {
+	if o == nil || o.Timeout == 0 {
+		return DefaultRollbackTimeout
+	}
+	return o.Timeout
+}
+
+func (o *WaitRollbackOption) interval() time.Duration {
+	if o == nil || o.Interval == 0 {
+		return DefaultRollbackInterval
+	}
+	return o.Interval
+}
+
+func (o *WaitRollbackOption) report(p RollbackProgress) {
+	if o == nil || o.ProgressFunc == nil {
+		return
+	}
+	o.ProgressFunc(p)
+}
+
+// GetRollbackProgress return RollbackProgress of snapshot
+func (s *Snapshot) GetRollbackProgress() RollbackProgress {
+	return RollbackProgress{
+		SnapshotID:  s.ID,
+		RollingBack: s.RUNNINGSTATUS == strconv.Itoa(StatusSnapshotRollingBack),
+		Progress:    parseIntOrUnknown(s.ROLLBACKRATE),
+		Speed:       parseIntOrUnknown(s.ROLLBACKSPEED),
+	}
+}
+
+// RollbackSnapshot start to rollback source LUN from snapshot by speed (SpeedLow to SpeedHighest).
+// snapshot must be activated, data of source LUN is overwritten.
+func (d *Device) RollbackSnapshot(ctx context.Context, snapshotID, speed int) error {
+	if speed < SpeedLow || speed > SpeedHighest {
+		return fmt.Errorf("invalid speed: %d", speed)
+	}
+
+	spath := "/snapshot/rollback"
+	param := struct {
+		ID            string `json:"ID"`
+		ROLLBACKSPEED string `json:"ROLLBACKSPEED"`
+	}{
+		ID:            strconv.Itoa(snapshotID),
+		ROLLBACKSPEED: strconv.Itoa(speed),
+	}
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+
+	req, err := d.newRequest(ctx, "PUT", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// StopRollbackSnapshot stop rollback of snapshot.
+// data of source LUN is incomplete after stopped, need to rollback again.
+func (d *Device) StopRollbackSnapshot(ctx context.Context, snapshotID int) error {
+	spath := "/snapshot/cancelrollback"
+	param := struct {
+		ID string `json:"ID"`
+	}{
+		ID: strconv.Itoa(snapshotID),
+	}
+	jb, err := json.Marshal(param)
+	if err != nil {
+		return fmt.Errorf(ErrCreatePostValue+": %w", err)
+	}
+
+	req, err := d.newRequest(ctx, "PUT", spath, bytes.NewBuffer(jb))
+	if err != nil {
+		return fmt.Errorf(ErrCreateRequest+": %w", err)
+	}
+
+	var i interface{} // this endpoint return N/A
+	if err = d.requestWithRetry(req, i, DefaultHTTPRetryCount); err != nil {
+		return fmt.Errorf(ErrRequestWithRetry+": %w", err)
+	}
+
+	return nil
+}
+
+// WaitSnapshotRollback wait that rollback of snapshot is done.
+func (d *Device) WaitSnapshotRollback(ctx context.Context, snapshotID int, opts *WaitRollbackOption) error {
+	timeout := time.After(opts.timeout())
+	ticker := time.NewTicker(opts.interval())
+	defer ticker.Stop()
+
+	for {
+		snapshot, err := d.GetSnapshot(ctx, snapshotID)
+		if err != nil {
+			return fmt.Errorf("failed to get snapshot (ID: %d): %w", snapshotID, err)
+		}
+		progress := snapshot.GetRollbackProgress()
+		opts.report(progress)
+
+		if snapshot.HEALTHSTATUS != strconv.Itoa(StatusHealth) {
+			return fmt.Errorf("snapshot health status is bad (HEALTHSTATUS: %s)", snapshot.HEALTHSTATUS)
+		}
+		if !progress.RollingBack {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			return ErrTimeoutWait
+		case <-ticker.C:
+		}
+	}
+}
+
+// RollbackSnapshotWithWait start to rollback source LUN from snapshot and wait to be done.
+func (d *Device) RollbackSnapshotWithWait(ctx context.Context, snapshotID, speed int, opts *WaitRollbackOption) error {
+	if err := d.RollbackSnapshot(ctx, snapshotID, speed); err != nil {
+		return fmt.Errorf("failed to rollback snapshot: %w", err)
+	}
+
+	if err := d.WaitSnapshotRollback(ctx, snapshotID, opts); err != nil {
+		return fmt.Errorf("failed to wait snapshot rollback: %w", err)
+	}
+
+	return nil
+}
+
+// RollbackVolumeSnapshot restore volume (= HyperMetroPair) in place from snapshot.
+// 1: suspend HyperMetroPair
+// 2: rollback LUN in preferred device (or device that has snapshot) and wait
+// 3: re-sync HyperMetroPair from restored device (and wait if opts.WaitSynced is set)
+// HyperMetroPair is resumed if failed after suspended.
+func (c *Client) RollbackVolumeSnapshot(ctx context.Context, hyperMetroPairID string, name uuid.UUID, speed int, opts *WaitRollbackOption) (err error) {
+	if err := c.requireBothDevices(ctx); err != nil {
+		return err
+	}
+	if speed < SpeedLow || speed > SpeedHighest {
+		return fmt.Errorf("invalid speed: %d", speed)
+	}
+
+	hmp, err := c.GetHyperMetroPair(ctx, hyperMetroPairID)
+	if err != nil {
+		return fmt.Errorf("failed to get HyperMetroPair: %w", err)
+	}
+	vs, err := c.GetVolumeSnapshot(ctx, hyperMetroPairID, name)
+	if err != nil {
+		return fmt.Errorf("failed to get volume snapshot: %w", err)
+	}
+
+	side := hmp.preferredSide()
+	if vs.Snapshot(side) == nil {
+		side = side.other()
+	}
+	snapshot := vs.Snapshot(side)
+
+	// 1: suspend HyperMetroPair
+	if hmp.RUNNINGSTATUS != strconv.Itoa(StatusPause) {
+		if err := c.SuspendHyperMetroPair(ctx, hmp.ID); err != nil {
+			return fmt.Errorf("failed to suspend HyperMetroPair: %w", err)
+		}
+		defer func() {
+			if err != nil {
+				if err := c.SyncHyperMetroPair(ctx, hmp.ID); err != nil {
+					c.Logger.Printf("failed to resume HyperMetroPair: %v", err)
+				}
+			}
+		}()
+	}
+
+	// 2: rollback LUN
+	if err := c.device(side).RollbackSnapshotWithWait(ctx, snapshot.ID, speed, opts); err != nil {
+		return fmt.Errorf("failed to rollback LUN in %s: %w", side, err)
+	}
+
+	// 3: re-sync HyperMetroPair
+	direction := SyncDirectionLocalToRemote
+	if side == SideRemote {
+		direction = SyncDirectionRemoteToLocal
+	}
+	if err := c.SetHyperMetroPairSyncDirection(ctx, hmp.ID, direction); err != nil {
+		return fmt.Errorf("failed to set sync direction: %w", err)
+	}
+	if err := c.SyncHyperMetroPair(ctx, hmp.ID); err != nil {
+		return fmt.Errorf("failed to re-sync HyperMetroPair: %w", err)
+	}
+	if opts != nil && opts.WaitSynced != nil {
+		if err := c.WaitHyperMetroPairSynced(ctx, hmp.ID, opts.WaitSynced); err != nil {
+			return fmt.Errorf("failed to wait HyperMetroPair synced: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package dorado
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+func TestDevice_RollbackSnapshotWithWait(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/snapshot/rollback", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	})
+	responses := []string{
+		`{"data": {"ID": "12", "HEALTHSTATUS": "1", "RUNNINGSTATUS": "44", "ROLLBACKRATE": "40", "ROLLBACKSPEED": "3", "TYPE": 27}, "error": {"code": 0, "description": "0"}}`,
+		`{"data": {"ID": "12", "HEALTHSTATUS": "1", "RUNNINGSTATUS": "43", "ROLLBACKRATE": "-1", "ROLLBACKSPEED": "-1", "TYPE": 27}, "error": {"code": 0, "description": "0"}}`,
+	}
+	count := 0
+	mux.HandleFunc("/snapshot/12", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, responses[count])
+		count++
+	})
+
+	var got []RollbackProgress
+	err := client.LocalDevice.RollbackSnapshotWithWait(context.Background(), 12, SpeedHigh, &WaitRollbackOption{
+		Interval: 10 * time.Millisecond,
+		ProgressFunc: func(p RollbackProgress) {
+			got = append(got, p)
+		},
+	})
+	if err != nil {
+		t.Errorf("RollbackSnapshotWithWait return err: %s", err)
+	}
+
+	want := []RollbackProgress{
+		{SnapshotID: 12, RollingBack: true, Progress: 40, Speed: 3},
+		{SnapshotID: 12, RollingBack: false, Progress: -1, Speed: -1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RollbackSnapshotWithWait report %+v, want %+v", got, want)
+	}
+}
+
+func TestDevice_RollbackSnapshot_InvalidSpeed(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	if err := client.LocalDevice.RollbackSnapshot(context.Background(), 12, 0); err == nil {
+		t.Errorf("RollbackSnapshot with invalid speed must return err")
+	}
+}
+
+func TestClient_RollbackVolumeSnapshot_Resume(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	name := EncodeSnapshotName(uuid.UUID{})
+	mux.HandleFunc("/HyperMetroPair/e4c2d1eaf02c0001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, testVolumeSnapshotPair)
+	})
+	mux.HandleFunc("/snapshot", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("filter") {
+		case "PARENTID::514":
+			fmt.Fprintf(w, `{"data": [{"ID": "1", "NAME": "%s", "PARENTID": "514", "PARENTTYPE": 11, "RUNNINGSTATUS": "43", "TYPE": 27}], "error": {"code": 0, "description": "0"}}`, name)
+		default:
+			fmt.Fprint(w, `{"data": [], "error": {"code": 0, "description": "0"}}`)
+		}
+	})
+
+	var calls []string
+	record := func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	}
+	mux.HandleFunc("/HyperMetroPair/disable_hcpair", record)
+	mux.HandleFunc("/HyperMetroPair/synchronize_hcpair", record)
+	mux.HandleFunc("/snapshot/rollback", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 1077937880, "description": "The snapshot is not activated."}}`)
+	})
+
+	if err := client.RollbackVolumeSnapshot(context.Background(), "e4c2d1eaf02c0001", uuid.UUID{}, SpeedHigh, nil); err == nil {
+		t.Fatalf("RollbackVolumeSnapshot must return err if failed to rollback")
+	}
+
+	want := []string{
+		"PUT /HyperMetroPair/disable_hcpair",
+		"PUT /snapshot/rollback",
+		"PUT /HyperMetroPair/synchronize_hcpair",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("RollbackVolumeSnapshot call %v, want %v", calls, want)
+	}
+}
@@ -4,26 +4,99 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"time"
 
 	"github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 	"golang.org/x/sync/errgroup"
 )
 
+// VolumeOption is optional parameter of volume workflows
+type VolumeOption struct {
+	// WaitSynced block until HyperMetroPair become StatusNormal if set.
+	// return immediately if nil.
+	WaitSynced *WaitSyncOption
+
+	// SourceSnapshotGroup is used by CreateVolumeFromSource.
+	// copy from snapshots in groups instead of current data of source if set.
+	SourceSnapshotGroup *VolumeSnapshotGroup
+
+	// LinkedClone is used by CreateVolumeFromSource.
+	// create LUN as clone that is not split from source if set.
+	// source volume can't be deleted until clone is split or deleted.
+	LinkedClone bool
+
+	// LUNSpec is properties of new LUNs in both devices. use default if nil.
+	// CreateVolumeFromSource copy by LUN Copy instead of LUN Clone if set.
+	LUNSpec *LUNSpec
+
+	// QoS is applied to LUNs in both devices by dedicated QoS policy if set.
+	QoS *QoSSpec
+}
+
+func (o *VolumeOption) lunSpec() *LUNSpec {
+	if o == nil {
+		return nil
+	}
+	return o.LUNSpec
+}
+
+// validate check value of VolumeOption
+func (o *VolumeOption) validate() error {
+	if o == nil {
+		return nil
+	}
+
+	if err := o.LUNSpec.Validate(); err != nil {
+		return fmt.Errorf("failed to validate LUN spec: %w", err)
+	}
+	if o.QoS != nil {
+		if err := o.QoS.Validate(); err != nil {
+			return fmt.Errorf("failed to validate QoS spec: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// setQoS set QoS of new HyperMetroPair if opts.QoS is set.
+func (c *Client) setQoS(ctx context.Context, hyperMetroPair *HyperMetroPair, opts *VolumeOption) error {
+	if opts == nil || opts.QoS == nil {
+		return nil
+	}
+
+	return c.SetVolumeQoS(ctx, hyperMetroPair.ID, opts.QoS)
+}
+
+// waitSynced sync HyperMetroPair and wait if opts.WaitSynced is set.
+func (c *Client) waitSynced(ctx context.Context, hyperMetroPair *HyperMetroPair, opts *VolumeOption) (*HyperMetroPair, error) {
+	if opts == nil || opts.WaitSynced == nil {
+		return hyperMetroPair, nil
+	}
+
+	if err := c.SyncHyperMetroPairWithWait(ctx, hyperMetroPair.ID, opts.WaitSynced); err != nil {
+		return nil, err
+	}
+
+	return c.GetHyperMetroPair(ctx, hyperMetroPair.ID)
+}
+
 // CreateVolumeRaw create blank HyperMetroPair
-func (c *Client) CreateVolumeRaw(ctx context.Context, name uuid.UUID, capacityGB int, storagePoolName, hyperMetroDomainID string) (*HyperMetroPair, error) {
-	if c.RemoteDevice == nil {
-		return nil, errors.New("Remote IPs is required")
+func (c *Client) CreateVolumeRaw(ctx context.Context, name uuid.UUID, capacityGB int, storagePoolName, hyperMetroDomainID string, opts *VolumeOption) (*HyperMetroPair, error) {
+	if err := c.requireBothDevices(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := opts.validate(); err != nil {
+		return nil, err
 	}
 
 	// create volume (= hypermetro enabled lun)
-	localLun, err := c.LocalDevice.CreateLUN(ctx, name, capacityGB, storagePoolName)
+	localLun, err := c.LocalDevice.CreateLUNWithSpec(ctx, name, capacityGB, storagePoolName, opts.lunSpec())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create lun in local device: %w", err)
 	}
 
-	remoteLun, err := c.RemoteDevice.CreateLUN(ctx, name, capacityGB, storagePoolName)
+	remoteLun, err := c.RemoteDevice.CreateLUNWithSpec(ctx, name, capacityGB, storagePoolName, opts.lunSpec())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create lun in remote device: %w", err)
 	}
@@ -33,13 +106,26 @@ func (c *Client) CreateVolumeRaw(ctx context.Context, name uuid.UUID, capacityGB
 		return nil, fmt.Errorf("failed to create HyperMetroPair: %w", err)
 	}
 
+	if err := c.setQoS(ctx, hyperMetroPair, opts); err != nil {
+		return nil, fmt.Errorf("failed to set QoS: %w", err)
+	}
+
+	hyperMetroPair, err = c.waitSynced(ctx, hyperMetroPair, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait HyperMetroPair synced: %w", err)
+	}
+
 	return hyperMetroPair, nil
 }
 
 // CreateVolumeFromSource create HyperMetroPair to copy from sourceHyperMetroPairID
-func (c *Client) CreateVolumeFromSource(ctx context.Context, name uuid.UUID, capacityGB int, storagePoolName, hyperMetroDomainID string, sourceHyperMetroPairID string) (*HyperMetroPair, error) {
-	if c.RemoteDevice == nil {
-		return nil, errors.New("Remote IPs is required")
+func (c *Client) CreateVolumeFromSource(ctx context.Context, name uuid.UUID, capacityGB int, storagePoolName, hyperMetroDomainID string, sourceHyperMetroPairID string, opts *VolumeOption) (*HyperMetroPair, error) {
+	if err := c.requireBothDevices(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := opts.validate(); err != nil {
+		return nil, err
 	}
 
 	source, err := c.GetHyperMetroPair(ctx, sourceHyperMetroPairID)
@@ -50,7 +136,7 @@ func (c *Client) CreateVolumeFromSource(ctx context.Context, name uuid.UUID, cap
 	var localLUNID, remoteLUNID int
 	eg := errgroup.Group{}
 	eg.Go(func() error {
-		localLun, err := c.LocalDevice.CreateLUNFromSource(ctx, source.LOCALOBJID, name, capacityGB, storagePoolName)
+		localLun, err := c.createLUNFromSource(ctx, SideLocal, source, name, capacityGB, storagePoolName, opts)
 		if err != nil {
 			return fmt.Errorf("failed to crteate lun from source in local device: %w", err)
 		}
@@ -59,7 +145,7 @@ func (c *Client) CreateVolumeFromSource(ctx context.Context, name uuid.UUID, cap
 		return nil
 	})
 	eg.Go(func() error {
-		remoteLun, err := c.RemoteDevice.CreateLUNFromSource(ctx, source.REMOTEOBJID, name, capacityGB, storagePoolName)
+		remoteLun, err := c.createLUNFromSource(ctx, SideRemote, source, name, capacityGB, storagePoolName, opts)
 		if err != nil {
 			return fmt.Errorf("failed to crteate lun from source in remote device: %w", err)
 		}
@@ -77,9 +163,47 @@ func (c *Client) CreateVolumeFromSource(ctx context.Context, name uuid.UUID, cap
 		return nil, fmt.Errorf("failed to create HyperMetroPair from source: %w", err)
 	}
 
+	if err := c.setQoS(ctx, hyperMetroPair, opts); err != nil {
+		return nil, fmt.Errorf("failed to set QoS: %w", err)
+	}
+
+	hyperMetroPair, err = c.waitSynced(ctx, hyperMetroPair, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait HyperMetroPair synced: %w", err)
+	}
+
 	return hyperMetroPair, nil
 }
 
+// createLUNFromSource create lun from LUN of source in side of device.
+// copy from snapshot of LUN in opts.SourceSnapshotGroup if set.
+// copy by LUN Copy if opts.LUNSpec is set, clone LUN inherit properties of source.
+func (c *Client) createLUNFromSource(ctx context.Context, side DeviceSide, source *HyperMetroPair, name uuid.UUID, capacityGB int, storagePoolName string, opts *VolumeOption) (*LUN, error) {
+	d := c.device(side)
+	if opts != nil && opts.LinkedClone {
+		if opts.SourceSnapshotGroup != nil {
+			return nil, fmt.Errorf("LinkedClone and SourceSnapshotGroup can't be set at the same time")
+		}
+		if opts.LUNSpec != nil {
+			return nil, fmt.Errorf("LinkedClone and LUNSpec can't be set at the same time")
+		}
+		return d.CreateLinkedCloneLUN(ctx, source.lunID(side), name, capacityGB)
+	}
+	if opts == nil || opts.SourceSnapshotGroup == nil {
+		if opts.lunSpec() != nil {
+			return d.CreateLUNFromSourceByLUNCopyWithSpec(ctx, source.lunID(side), name, capacityGB, storagePoolName, opts.lunSpec())
+		}
+		return d.CreateLUNFromSource(ctx, source.lunID(side), name, capacityGB, storagePoolName)
+	}
+
+	snapshot, err := opts.SourceSnapshotGroup.snapshotOf(ctx, d, side, source.lunID(side))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshot of source: %w", err)
+	}
+
+	return d.CreateLUNFromSnapshotWithSpec(ctx, snapshot.ID, name, capacityGB, storagePoolName, opts.lunSpec())
+}
+
 // CreateLUNFromSource create lun from source lun
 // low level function for CreateVolumeFromSource
 func (d *Device) CreateLUNFromSource(ctx context.Context, sourceLUNID int, name uuid.UUID, capacityGB int, storagePoolName string) (*LUN, error) {
@@ -87,10 +211,11 @@ func (d *Device) CreateLUNFromSource(ctx context.Context, sourceLUNID int, name
 }
 
 // CreateLUNFromSourceByLUNClone create lun from source lun by LUN Clone.
+// wait to finish split of clone LUN.
 func (d *Device) CreateLUNFromSourceByLUNClone(ctx context.Context, sourceLUNID int, name uuid.UUID, capacityGB int) (*LUN, error) {
-	cloneLUN, err := d.CreateCloneLUN(ctx, sourceLUNID, name)
+	cloneLUN, err := d.CreateLinkedCloneLUN(ctx, sourceLUNID, name, capacityGB)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create clone LUN: %w", err)
+		return nil, err
 	}
 	defer func() {
 		if err != nil {
@@ -100,38 +225,27 @@ func (d *Device) CreateLUNFromSourceByLUNClone(ctx context.Context, sourceLUNID
 		}
 	}()
 
-	if cloneLUN.CAPACITY < capacityGB*CapacityUnit {
-		if err := d.ExpandLUN(ctx, cloneLUN.ID, capacityGB); err != nil {
-			return nil, fmt.Errorf("failed to expand LUN: %w", err)
-		}
-	}
-
-	if err := d.SplitCloneLUN(ctx, cloneLUN.ID); err != nil {
+	if err = d.SplitCloneLUN(ctx, cloneLUN.ID); err != nil {
 		return nil, fmt.Errorf("failed to split clone LUN: %w", err)
 	}
 
-	for i := 0; i < DefaultCopyTimeoutSecond; i++ {
-		isReady, err := d.lunIsReady(ctx, cloneLUN.ID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to wait that LUN is ready: %w", err)
-		}
-
-		if isReady == true {
-			return d.GetLUN(ctx, cloneLUN.ID)
-		}
-
-		time.Sleep(1 * time.Second)
+	lun, err := d.WaitSplitCloneLUN(ctx, cloneLUN.ID)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, ErrTimeoutWait
+	return lun, nil
 }
 
 // CreateLUNFromSourceByLUNCopy create lun from source lun by LUN Copy.
 func (d *Device) CreateLUNFromSourceByLUNCopy(ctx context.Context, sourceLUNID int, name uuid.UUID, capacityGB int, storagePoolName string) (*LUN, error) {
-	snapshotName, err := uuid.NewV4()
-	if err != nil {
-		return nil, err
-	}
+	return d.CreateLUNFromSourceByLUNCopyWithSpec(ctx, sourceLUNID, name, capacityGB, storagePoolName, nil)
+}
+
+// CreateLUNFromSourceByLUNCopyWithSpec create lun from source lun by LUN Copy.
+// spec is properties of new LUN, use default if nil.
+func (d *Device) CreateLUNFromSourceByLUNCopyWithSpec(ctx context.Context, sourceLUNID int, name uuid.UUID, capacityGB int, storagePoolName string, spec *LUNSpec) (*LUN, error) {
+	snapshotName := uuid.NewV4()
 
 	snapshot, err := d.CreateSnapshotWithWait(ctx, sourceLUNID, snapshotName, "")
 	if err != nil {
@@ -150,12 +264,42 @@ func (d *Device) CreateLUNFromSourceByLUNCopy(ctx context.Context, sourceLUNID i
 		return nil, fmt.Errorf("failed to activate snapshot: %w", err)
 	}
 
-	targetLUN, err := d.CreateLUNWithWait(ctx, name, capacityGB, storagePoolName)
+	return d.CreateLUNFromSnapshotWithSpec(ctx, snapshot.ID, name, capacityGB, storagePoolName, spec)
+}
+
+// CreateLUNFromSnapshot create lun from activated snapshot by LUN Copy.
+// this function is local mode of CreateVolumeFromSnapshot, snapshot is kept after copied.
+func (d *Device) CreateLUNFromSnapshot(ctx context.Context, snapshotID int, name uuid.UUID, capacityGB int, storagePoolName string) (*LUN, error) {
+	return d.CreateLUNFromSnapshotWithSpec(ctx, snapshotID, name, capacityGB, storagePoolName, nil)
+}
+
+// CreateLUNFromSnapshotWithSpec create lun from activated snapshot by LUN Copy.
+// spec is properties of new LUN, use default if nil.
+func (d *Device) CreateLUNFromSnapshotWithSpec(ctx context.Context, snapshotID int, name uuid.UUID, capacityGB int, storagePoolName string, spec *LUNSpec) (*LUN, error) {
+	snapshot, err := d.GetSnapshot(ctx, snapshotID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshot: %w", err)
+	}
+	if snapshot.RUNNINGSTATUS != strconv.Itoa(StatusSnapshotActive) {
+		return nil, fmt.Errorf("snapshot is not activated (RUNNINGSTATUS: %s)", snapshot.RUNNINGSTATUS)
+	}
+	if userCapacity, err := strconv.Atoi(snapshot.USERCAPACITY); err == nil && userCapacity > capacityGB*CapacityUnit {
+		return nil, fmt.Errorf("capacity must be larger than snapshot (capacity: %dGB, snapshot: %d sectors)", capacityGB, userCapacity)
+	}
+
+	targetLUN, err := d.CreateLUNWithSpecWithWait(ctx, name, capacityGB, storagePoolName, spec)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create raw LUN: %w", err)
 	}
+	defer func() {
+		if err != nil {
+			if err := d.DeleteLUN(ctx, targetLUN.ID); err != nil {
+				d.Logger.Printf("failed to delete LUN: %v", err)
+			}
+		}
+	}()
 
-	luncopy, err := d.CreateLUNCopy(ctx, snapshot.ID, targetLUN.ID)
+	luncopy, err := d.CreateLUNCopy(ctx, snapshotID, targetLUN.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create luncopy object: %w", err)
 	}
@@ -165,7 +309,7 @@ func (d *Device) CreateLUNFromSourceByLUNCopy(ctx context.Context, sourceLUNID i
 		}
 	}()
 
-	if err := d.StartLUNCopyWithWait(ctx, luncopy.ID, 0); err != nil {
+	if err = d.StartLUNCopyWithWaitOption(ctx, luncopy.ID, nil); err != nil {
 		return nil, fmt.Errorf("failed to copy lun: %w", err)
 	}
 
@@ -174,8 +318,8 @@ func (d *Device) CreateLUNFromSourceByLUNCopy(ctx context.Context, sourceLUNID i
 
 // DeleteVolume delete HyperMetroPair
 func (c *Client) DeleteVolume(ctx context.Context, hyperMetroPairID string) error {
-	if c.RemoteDevice == nil {
-		return errors.New("Remote IPs is required")
+	if err := c.requireBothDevices(ctx); err != nil {
+		return err
 	}
 
 	// 1: delete HyperMetro Pair
@@ -187,11 +331,22 @@ func (c *Client) DeleteVolume(ctx context.Context, hyperMetroPairID string) erro
 		return fmt.Errorf("failed to get HyperMetro Pair: %w", err)
 	}
 
-	// 2: delete LUN Group Associate
 	llun, err := c.LocalDevice.GetLUN(ctx, hmp.LOCALOBJID)
 	if err != nil {
 		return fmt.Errorf("failed to get lun information: %w", err)
 	}
+	if err := llun.checkNoClones(); err != nil {
+		return err
+	}
+	rlun, err := c.RemoteDevice.GetLUN(ctx, hmp.REMOTEOBJID)
+	if err != nil {
+		return fmt.Errorf("failed to get lun information: %w", err)
+	}
+	if err := rlun.checkNoClones(); err != nil {
+		return err
+	}
+
+	// 2: delete LUN Group Associate
 	if llun.ISADD2LUNGROUP == true {
 		lLungroup, err := c.LocalDevice.GetLunGroupByLunID(ctx, hmp.LOCALOBJID)
 		if err != nil {
@@ -203,10 +358,6 @@ func (c *Client) DeleteVolume(ctx context.Context, hyperMetroPairID string) erro
 		}
 	}
 
-	rlun, err := c.RemoteDevice.GetLUN(ctx, hmp.REMOTEOBJID)
-	if err != nil {
-		return fmt.Errorf("failed to get lun information: %w", err)
-	}
 	if rlun.ISADD2LUNGROUP == true {
 		rLungroup, err := c.RemoteDevice.GetLunGroupByLunID(ctx, hmp.REMOTEOBJID)
 		if err != nil {
@@ -244,9 +395,9 @@ func (c *Client) DeleteVolume(ctx context.Context, hyperMetroPairID string) erro
 }
 
 // ExtendVolume expand HyperMetroPair
-func (c *Client) ExtendVolume(ctx context.Context, hyperMetroPairID string, newVolumeSizeGb int) error {
-	if c.RemoteDevice == nil {
-		return errors.New("Remote IPs is required")
+func (c *Client) ExtendVolume(ctx context.Context, hyperMetroPairID string, newVolumeSizeGb int, opts *VolumeOption) error {
+	if err := c.requireBothDevices(ctx); err != nil {
+		return err
 	}
 
 	// 1: Suspend HyperMetro Pair
@@ -279,89 +430,290 @@ func (c *Client) ExtendVolume(ctx context.Context, hyperMetroPairID string, newV
 	if err != nil {
 		return fmt.Errorf("failed to re-sync HyperMetro Pair: %w", err)
 	}
+	if _, err = c.waitSynced(ctx, hmp, opts); err != nil {
+		return fmt.Errorf("failed to wait HyperMetro Pair synced: %w", err)
+	}
 
 	return nil
 }
 
+// AttachVolumeOption is optional parameter of AttachVolume
+type AttachVolumeOption struct {
+	// CHAP enable CHAP authentication of initiator if set.
+	// CHAP is disabled if nil.
+	CHAP *CHAPCredential
+
+	// Host set OS type and access mode of host if set.
+	Host *HostOption
+	// RemoteHost is used instead of Host in remote device if set.
+	// ex: set PathType to PathTypeNonOptimized for HyperMetro ALUA.
+	RemoteHost *HostOption
+
+	// HostLUNID request host LUN ID if set. device choose host LUN ID if nil.
+	// this option can not use with AttachVolumes.
+	HostLUNID *int
+	// SameHostLUNID request host LUN ID that chosen in local device to remote device.
+	// this option is used only in Client.
+	SameHostLUNID bool
+}
+
+// withHostLUNID return copy of AttachVolumeOption that set HostLUNID
+func (o *AttachVolumeOption) withHostLUNID(hostLUNID int) *AttachVolumeOption {
+	opts := &AttachVolumeOption{}
+	if o != nil {
+		*opts = *o
+	}
+
+	opts.HostLUNID = &hostLUNID
+	return opts
+}
+
+// remoteOption return AttachVolumeOption for remote device
+func (o *AttachVolumeOption) remoteOption() *AttachVolumeOption {
+	if o == nil || o.RemoteHost == nil {
+		return o
+	}
+
+	remote := *o
+	remote.Host = o.RemoteHost
+	return &remote
+}
+
+// ConnectionInfo is information that host need to connect attached LUN.
+type ConnectionInfo struct {
+	TargetIQNs []string
+	PortalIPs  []string
+	HostLUNID  int
+	CHAP       *CHAPCredential
+}
+
+// VolumeConnectionInfo is ConnectionInfo of HyperMetroPair
+type VolumeConnectionInfo struct {
+	Local  *ConnectionInfo
+	Remote *ConnectionInfo
+}
+
 // AttachVolume create mapping to host
-func (c *Client) AttachVolume(ctx context.Context, hyperMetroPairID, hostname, iqn string) error {
-	if c.RemoteDevice == nil {
-		return errors.New("Remote IPs is required")
+func (c *Client) AttachVolume(ctx context.Context, hyperMetroPairID, hostname, iqn string, opts *AttachVolumeOption) (*VolumeConnectionInfo, error) {
+	if err := c.requireBothDevices(ctx); err != nil {
+		return nil, err
 	}
 
 	volume, err := c.GetHyperMetroPair(ctx, hyperMetroPairID)
 	if err != nil {
-		return fmt.Errorf("failed to get volume information: %w", err)
+		return nil, fmt.Errorf("failed to get volume information: %w", err)
 	}
 
-	err = c.LocalDevice.AttachVolume(ctx, c.PortGroupName, hostname, iqn, volume.LOCALOBJID)
+	localInfo, err := c.LocalDevice.AttachVolume(ctx, c.PortGroupName, hostname, iqn, volume.LOCALOBJID, opts)
 	if err != nil {
-		return fmt.Errorf("failed to attach volume in Local Device: %w", err)
+		return nil, fmt.Errorf("failed to attach volume in Local Device: %w", err)
 	}
-	err = c.RemoteDevice.AttachVolume(ctx, c.PortGroupName, hostname, iqn, volume.REMOTEOBJID)
+	remoteOpts := opts.remoteOption()
+	if opts != nil && opts.SameHostLUNID {
+		remoteOpts = remoteOpts.withHostLUNID(localInfo.HostLUNID)
+	}
+	remoteInfo, err := c.RemoteDevice.AttachVolume(ctx, c.PortGroupName, hostname, iqn, volume.REMOTEOBJID, remoteOpts)
 	if err != nil {
-		return fmt.Errorf("failed to attach volume in Remote Device: %w", err)
+		return nil, fmt.Errorf("failed to attach volume in Remote Device: %w", err)
 	}
 
-	return nil
+	return &VolumeConnectionInfo{
+		Local:  localInfo,
+		Remote: remoteInfo,
+	}, nil
 }
 
 // AttachVolume create mapping to host in device
-func (d *Device) AttachVolume(ctx context.Context, portgroupName, hostname, iqn string, lunID int) error {
+func (d *Device) AttachVolume(ctx context.Context, portgroupName, hostname, iqn string, lunID int, opts *AttachVolumeOption) (*ConnectionInfo, error) {
 	// wrapper function for client.AttachVolume
-	portgroups, err := d.GetPortGroups(ctx, NewSearchQueryName(portgroupName))
-	if err != nil {
-		return fmt.Errorf("failed to get portgroup: %w", err)
+	if opts == nil {
+		opts = &AttachVolumeOption{}
 	}
-	if len(portgroups) != 1 {
-		return errors.New("found multiple portgroup in same PortGroup name")
+
+	attachment, err := d.prepareAttach(ctx, portgroupName, hostname, []string{iqn}, opts)
+	if err != nil {
+		return nil, err
 	}
-	portgroup := portgroups[0]
 
-	hostgroup, host, err := d.GetHostGroupForce(ctx, hostname)
+	if opts.HostLUNID != nil {
+		err = d.AssociateLunWithHostLUNID(ctx, attachment.LunGroup.ID, lunID, *opts.HostLUNID)
+	} else {
+		err = d.AssociateLun(ctx, attachment.LunGroup.ID, lunID)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to get hostgroup: %w", err)
+		return nil, fmt.Errorf("failed to associate lun to lungroup: %w", err)
 	}
-	_, err = d.GetInitiatorForce(ctx, iqn)
+
+	info, err := d.getConnectionInfo(ctx, attachment.PortGroup.ID, attachment.Host.ID, lunID, opts.CHAP)
 	if err != nil {
-		return fmt.Errorf("failed to get initiator: %w", err)
+		return nil, err
 	}
-	initiatorUpdateParam := UpdateInitiatorParam{
-		ID:         iqn,
-		TYPE:       strconv.Itoa(TypeInitiator),
-		USECHAP:    "false",
-		PARENTID:   strconv.Itoa(host.ID),
-		PARENTTYPE: strconv.Itoa(TypeHost),
+	if opts.HostLUNID != nil && info.HostLUNID != *opts.HostLUNID {
+		if err := d.DisAssociateLun(ctx, attachment.LunGroup.ID, lunID); err != nil {
+			d.Logger.Printf("failed to disassociate lun from lungroup: %v", err)
+		}
+		return nil, fmt.Errorf("%w (requested: %d, actual: %d)", ErrHostLUNIDMismatch, *opts.HostLUNID, info.HostLUNID)
 	}
-	_, err = d.UpdateInitiator(ctx, iqn, initiatorUpdateParam) // set PARENTID (= host.ID)
+
+	return info, nil
+}
+
+// validate check value of AttachVolumeOption
+func (o *AttachVolumeOption) validate() error {
+	if o.CHAP != nil {
+		if err := o.CHAP.Validate(); err != nil {
+			return fmt.Errorf("failed to validate CHAP credential: %w", err)
+		}
+	}
+	if o.Host != nil {
+		if err := o.Host.Validate(); err != nil {
+			return fmt.Errorf("failed to validate host option: %w", err)
+		}
+	}
+	if o.HostLUNID != nil {
+		if *o.HostLUNID < MinHostLUNID || *o.HostLUNID > MaxHostLUNID {
+			return fmt.Errorf("host LUN ID must be between %d and %d", MinHostLUNID, MaxHostLUNID)
+		}
+	}
+
+	return nil
+}
+
+// hostAttachment is objects that need to attach LUN to host.
+type hostAttachment struct {
+	PortGroup   *PortGroup
+	HostGroup   *HostGroup
+	Host        *Host
+	LunGroup    *LunGroup
+	MappingView *MappingView
+}
+
+// prepareAttach get (or create) objects that need to attach LUN to host, and do mapping.
+func (d *Device) prepareAttach(ctx context.Context, portgroupName, hostname string, iqns []string, opts *AttachVolumeOption) (*hostAttachment, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	portgroup, err := d.GetPortGroupByName(ctx, portgroupName)
 	if err != nil {
-		return fmt.Errorf("failed to set parameter for initiator: %w", err)
+		return nil, fmt.Errorf("failed to get portgroup: %w", err)
 	}
 
-	lungroup, err := d.GetLunGroupForce(ctx, hostname)
+	hostgroup, host, err := d.GetHostGroupForce(ctx, hostname)
 	if err != nil {
-		return fmt.Errorf("failed to get lungroup: %w", err)
+		return nil, fmt.Errorf("failed to get hostgroup: %w", err)
+	}
+	if opts.Host.needUpdate(host) {
+		_, err = d.UpdateHost(ctx, host.ID, *opts.Host)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update host: %w", err)
+		}
+	}
+	for _, iqn := range iqns {
+		if err := d.setupInitiator(ctx, iqn, host.ID, opts); err != nil {
+			return nil, err
+		}
 	}
 
-	err = d.AssociateLun(ctx, lungroup.ID, lunID)
+	lungroup, err := d.GetLunGroupForce(ctx, hostname)
 	if err != nil {
-		return fmt.Errorf("failed to associate lun to lungroup: %w", err)
+		return nil, fmt.Errorf("failed to get lungroup: %w", err)
 	}
 
 	mappingview, err := d.GetMappingViewForce(ctx, hostname)
 	if err != nil {
-		return fmt.Errorf("failed to get mappingview: %w", err)
+		return nil, fmt.Errorf("failed to get mappingview: %w", err)
 	}
 
 	err = d.DoMapping(ctx, mappingview, hostgroup, lungroup, portgroup.ID)
 	if err != nil {
-		return fmt.Errorf("failed to associate object to mappingview: %w", err)
+		return nil, fmt.Errorf("failed to associate object to mappingview: %w", err)
+	}
+
+	return &hostAttachment{
+		PortGroup:   portgroup,
+		HostGroup:   hostgroup,
+		Host:        host,
+		LunGroup:    lungroup,
+		MappingView: mappingview,
+	}, nil
+}
+
+// setupInitiator get (or create) initiator and set host and option.
+func (d *Device) setupInitiator(ctx context.Context, iqn string, hostID int, opts *AttachVolumeOption) error {
+	initiator, err := d.GetInitiatorForce(ctx, iqn)
+	if err != nil {
+		return fmt.Errorf("failed to get initiator: %w", err)
+	}
+	// keep current CHAP setting if opts.CHAP is not set
+	initiatorUpdateParam := newUpdateInitiatorParam(initiator)
+	initiatorUpdateParam.PARENTID = strconv.Itoa(hostID)
+	initiatorUpdateParam.PARENTTYPE = strconv.Itoa(TypeHost)
+	if opts.CHAP != nil {
+		opts.CHAP.toParam(&initiatorUpdateParam)
+	}
+	if opts.Host != nil && opts.Host.AccessMode != nil {
+		opts.Host.AccessMode.toParam(&initiatorUpdateParam)
+	}
+	_, err = d.UpdateInitiator(ctx, iqn, initiatorUpdateParam) // set PARENTID (= host.ID)
+	if err != nil {
+		return fmt.Errorf("failed to set parameter for initiator: %w", err)
 	}
 
 	return nil
 }
 
+// getConnectionInfo collect ConnectionInfo of LUN that attached to host.
+func (d *Device) getConnectionInfo(ctx context.Context, portgroupID, hostID, lunID int, chap *CHAPCredential) (*ConnectionInfo, error) {
+	infos, err := d.getConnectionInfos(ctx, portgroupID, hostID, []int{lunID}, chap)
+	if err != nil {
+		return nil, err
+	}
+
+	info, ok := infos[lunID]
+	if !ok {
+		return nil, fmt.Errorf("LUN (ID: %d) is not associated host (ID: %d)", lunID, hostID)
+	}
+
+	return info, nil
+}
+
+// getConnectionInfos collect ConnectionInfo of LUNs that attached to host.
+// return map of LUN ID to ConnectionInfo, not associated LUN is not included.
+func (d *Device) getConnectionInfos(ctx context.Context, portgroupID, hostID int, lunIDs []int, chap *CHAPCredential) (map[int]*ConnectionInfo, error) {
+	targetIQNs, err := d.GetTargetIQNs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target IQNs: %w", err)
+	}
+	portalIPs, err := d.GetPortalIPAddresses(ctx, portgroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portal IP addresses: %w", err)
+	}
+	hostLUNIDs, err := d.GetHostLUNIDs(ctx, hostID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get host LUN IDs: %w", err)
+	}
+
+	infos := map[int]*ConnectionInfo{}
+	for _, lunID := range lunIDs {
+		hostLUNID, ok := hostLUNIDs[lunID]
+		if !ok {
+			continue
+		}
+
+		infos[lunID] = &ConnectionInfo{
+			TargetIQNs: targetIQNs,
+			PortalIPs:  portalIPs,
+			HostLUNID:  hostLUNID,
+			CHAP:       chap,
+		}
+	}
+
+	return infos, nil
+}
+
 // DetachVolume delete mapping from host
+// in degraded mode, detach in reachable device and detach in other device after recovered.
 func (c *Client) DetachVolume(ctx context.Context, hyperMetroPairID string) error {
 	if c.RemoteDevice == nil {
 		return errors.New("Remote IPs is required")
@@ -372,16 +724,14 @@ func (c *Client) DetachVolume(ctx context.Context, hyperMetroPairID string) erro
 		return fmt.Errorf("failed to get hypermetro pair: %w", err)
 	}
 
-	err = c.LocalDevice.DetachVolume(ctx, volume.LOCALOBJID)
-	if err != nil {
-		return fmt.Errorf("failed to detach volume in Local Device: %w", err)
-	}
-	err = c.RemoteDevice.DetachVolume(ctx, volume.REMOTEOBJID)
-	if err != nil {
-		return fmt.Errorf("failed to detach volume in Remote Device: %w", err)
-	}
-
-	return nil
+	return c.runBothDevices(ctx, fmt.Sprintf("detach volume (ID: %s)", hyperMetroPairID),
+		func(ctx context.Context) error {
+			return c.LocalDevice.DetachVolume(ctx, volume.LOCALOBJID)
+		},
+		func(ctx context.Context) error {
+			return c.RemoteDevice.DetachVolume(ctx, volume.REMOTEOBJID)
+		},
+	)
 }
 
 // DetachVolume delete mapping from host in device
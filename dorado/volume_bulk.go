@@ -0,0 +1,389 @@
+package dorado
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// LUNResult is result of bulk operation per LUN
+type LUNResult struct {
+	LUNID          int
+	ConnectionInfo *ConnectionInfo // only set by AttachVolumes
+	Err            error
+}
+
+// VolumeResult is result of bulk operation per volume
+type VolumeResult struct {
+	HyperMetroPairID string
+	ConnectionInfo   *VolumeConnectionInfo // only set by AttachVolumes
+	Err              error
+}
+
+// AttachVolumes create mapping of multiple HyperMetroPairs to host.
+// objects of host are resolved only once, and return error if failed to resolve.
+// error of each volume is set to VolumeResult.Err.
+func (c *Client) AttachVolumes(ctx context.Context, hostname string, iqns []string, hyperMetroPairIDs []string, opts *AttachVolumeOption) ([]VolumeResult, error) {
+	if err := c.requireBothDevices(ctx); err != nil {
+		return nil, err
+	}
+
+	results, localLUNIDs, remoteLUNIDs, err := c.newVolumeResults(ctx, hyperMetroPairIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts != nil && opts.SameHostLUNID {
+		return c.attachVolumesSameHostLUNID(ctx, hostname, iqns, results, localLUNIDs, remoteLUNIDs, opts)
+	}
+
+	var localResults, remoteResults []LUNResult
+	eg := errgroup.Group{}
+	eg.Go(func() error {
+		var err error
+		localResults, err = c.LocalDevice.AttachVolumes(ctx, c.PortGroupName, hostname, iqns, localLUNIDs, opts)
+		if err != nil {
+			return fmt.Errorf("failed to attach volumes in Local Device: %w", err)
+		}
+		return nil
+	})
+	eg.Go(func() error {
+		var err error
+		remoteResults, err = c.RemoteDevice.AttachVolumes(ctx, c.PortGroupName, hostname, iqns, remoteLUNIDs, opts.remoteOption())
+		if err != nil {
+			return fmt.Errorf("failed to attach volumes in Remote Device: %w", err)
+		}
+		return nil
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	mergeLUNResults(results, localResults, remoteResults, "attach")
+	return results, nil
+}
+
+// attachVolumesSameHostLUNID attach volumes to local device, and attach to remote device
+// with host LUN ID that chosen in local device.
+func (c *Client) attachVolumesSameHostLUNID(ctx context.Context, hostname string, iqns []string, results []VolumeResult, localLUNIDs, remoteLUNIDs []int, opts *AttachVolumeOption) ([]VolumeResult, error) {
+	localResults, err := c.LocalDevice.AttachVolumes(ctx, c.PortGroupName, hostname, iqns, localLUNIDs, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach volumes in Local Device: %w", err)
+	}
+
+	hostLUNIDs := make([]int, len(localResults))
+	for i, r := range localResults {
+		hostLUNIDs[i] = -1 // skip LUN that failed in local device
+		if r.Err == nil {
+			hostLUNIDs[i] = r.ConnectionInfo.HostLUNID
+		}
+	}
+
+	remoteResults, err := c.RemoteDevice.attachVolumes(ctx, c.PortGroupName, hostname, iqns, remoteLUNIDs, hostLUNIDs, opts.remoteOption())
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach volumes in Remote Device: %w", err)
+	}
+
+	mergeLUNResults(results, localResults, remoteResults, "attach")
+	return results, nil
+}
+
+// DetachVolumes delete mapping of multiple HyperMetroPairs from host.
+// error of each volume is set to VolumeResult.Err.
+func (c *Client) DetachVolumes(ctx context.Context, hostname string, hyperMetroPairIDs []string) ([]VolumeResult, error) {
+	if c.RemoteDevice == nil {
+		return nil, errors.New("Remote IPs is required")
+	}
+
+	results, localLUNIDs, remoteLUNIDs, err := c.newVolumeResults(ctx, hyperMetroPairIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if unreachable := c.UnreachableSide(); unreachable != SideNone {
+		return c.detachVolumesDegraded(ctx, hostname, results, localLUNIDs, remoteLUNIDs, unreachable)
+	}
+
+	var localResults, remoteResults []LUNResult
+	eg := errgroup.Group{}
+	eg.Go(func() error {
+		var err error
+		localResults, err = c.LocalDevice.DetachVolumes(ctx, hostname, localLUNIDs)
+		if err != nil {
+			return fmt.Errorf("failed to detach volumes in Local Device: %w", err)
+		}
+		return nil
+	})
+	eg.Go(func() error {
+		var err error
+		remoteResults, err = c.RemoteDevice.DetachVolumes(ctx, hostname, remoteLUNIDs)
+		if err != nil {
+			return fmt.Errorf("failed to detach volumes in Remote Device: %w", err)
+		}
+		return nil
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	mergeLUNResults(results, localResults, remoteResults, "detach")
+	return results, nil
+}
+
+// detachVolumesDegraded detach volumes in reachable device, and detach in unreachable device after recovered.
+func (c *Client) detachVolumesDegraded(ctx context.Context, hostname string, results []VolumeResult, localLUNIDs, remoteLUNIDs []int, unreachable DeviceSide) ([]VolumeResult, error) {
+	lunIDs := map[DeviceSide][]int{
+		SideLocal:  localLUNIDs,
+		SideRemote: remoteLUNIDs,
+	}
+	reachable := unreachable.other()
+
+	reachableResults, err := c.device(reachable).DetachVolumes(ctx, hostname, lunIDs[reachable])
+	if err != nil {
+		return nil, fmt.Errorf("failed to detach volumes in %s: %w", reachable, err)
+	}
+	// results of unreachable device is regarded as success, it is retried after recovered.
+	var pendingResults []LUNResult
+	for _, id := range lunIDs[unreachable] {
+		pendingResults = append(pendingResults, LUNResult{LUNID: id})
+	}
+
+	if reachable == SideLocal {
+		mergeLUNResults(results, reachableResults, pendingResults, "detach")
+	} else {
+		mergeLUNResults(results, pendingResults, reachableResults, "detach")
+	}
+
+	unreachableLUNIDs := lunIDs[unreachable]
+	c.addPending(unreachable, fmt.Sprintf("detach volumes (host: %s)", hostname), func(ctx context.Context) error {
+		lunResults, err := c.device(unreachable).DetachVolumes(ctx, hostname, unreachableLUNIDs)
+		if err != nil {
+			return err
+		}
+		for _, r := range lunResults {
+			if r.Err != nil {
+				return fmt.Errorf("failed to detach LUN (ID: %d): %w", r.LUNID, r.Err)
+			}
+		}
+		return nil
+	})
+
+	return results, nil
+}
+
+// newVolumeResults create VolumeResult and LUN IDs of each device.
+// HyperMetroPair that failed to get is set error, and LUN ID is not included.
+func (c *Client) newVolumeResults(ctx context.Context, hyperMetroPairIDs []string) ([]VolumeResult, []int, []int, error) {
+	results := make([]VolumeResult, len(hyperMetroPairIDs))
+	var localLUNIDs, remoteLUNIDs []int
+	for i, id := range hyperMetroPairIDs {
+		results[i].HyperMetroPairID = id
+
+		hmp, err := c.GetHyperMetroPair(ctx, id)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, nil, nil, ctx.Err()
+			}
+			results[i].Err = fmt.Errorf("failed to get volume information: %w", err)
+			continue
+		}
+		localLUNIDs = append(localLUNIDs, hmp.LOCALOBJID)
+		remoteLUNIDs = append(remoteLUNIDs, hmp.REMOTEOBJID)
+	}
+
+	return results, localLUNIDs, remoteLUNIDs, nil
+}
+
+// mergeLUNResults set LUNResult of each device to VolumeResult.
+// LUNResult is the same order of VolumeResult that not have error.
+func mergeLUNResults(results []VolumeResult, localResults, remoteResults []LUNResult, operation string) {
+	j := 0
+	for i := range results {
+		if results[i].Err != nil {
+			continue
+		}
+		local, remote := localResults[j], remoteResults[j]
+		j++
+
+		switch {
+		case local.Err != nil:
+			results[i].Err = fmt.Errorf("failed to %s volume in Local Device: %w", operation, local.Err)
+		case remote.Err != nil:
+			results[i].Err = fmt.Errorf("failed to %s volume in Remote Device: %w", operation, remote.Err)
+		case local.ConnectionInfo != nil || remote.ConnectionInfo != nil:
+			results[i].ConnectionInfo = &VolumeConnectionInfo{
+				Local:  local.ConnectionInfo,
+				Remote: remote.ConnectionInfo,
+			}
+		}
+	}
+}
+
+// AttachVolumes create mapping of multiple LUNs to host in device.
+// LUNs are associated to lungroup in one request, retry each LUN if failed.
+func (d *Device) AttachVolumes(ctx context.Context, portgroupName, hostname string, iqns []string, lunIDs []int, opts *AttachVolumeOption) ([]LUNResult, error) {
+	return d.attachVolumes(ctx, portgroupName, hostname, iqns, lunIDs, nil, opts)
+}
+
+// attachVolumes create mapping of multiple LUNs to host in device.
+// request host LUN ID of the same index in hostLUNIDs if hostLUNIDs is not nil,
+// and skip LUN that host LUN ID is negative.
+func (d *Device) attachVolumes(ctx context.Context, portgroupName, hostname string, iqns []string, lunIDs []int, hostLUNIDs []int, opts *AttachVolumeOption) ([]LUNResult, error) {
+	if opts == nil {
+		opts = &AttachVolumeOption{}
+	}
+	if opts.HostLUNID != nil {
+		return nil, errors.New("HostLUNID can not use with AttachVolumes")
+	}
+
+	attachment, err := d.prepareAttach(ctx, portgroupName, hostname, iqns, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	results := newLUNResults(lunIDs)
+	if len(lunIDs) == 0 {
+		return results, nil
+	}
+
+	if hostLUNIDs != nil {
+		for i := range results {
+			if hostLUNIDs[i] < 0 {
+				results[i].Err = errors.New("skipped because failed to attach in other device")
+				continue
+			}
+
+			if err := d.AssociateLunWithHostLUNID(ctx, attachment.LunGroup.ID, results[i].LUNID, hostLUNIDs[i]); err != nil {
+				results[i].Err = fmt.Errorf("failed to associate lun to lungroup: %w", err)
+			}
+		}
+	} else if err := d.AssociateLuns(ctx, attachment.LunGroup.ID, lunIDs); err != nil {
+		d.Logger.Printf("failed to associate luns in one request, retry each lun: %v", err)
+
+		associated, err := d.GetHostLUNIDs(ctx, attachment.Host.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get host LUN IDs: %w", err)
+		}
+		for i := range results {
+			if _, ok := associated[results[i].LUNID]; ok {
+				continue
+			}
+
+			if err := d.AssociateLun(ctx, attachment.LunGroup.ID, results[i].LUNID); err != nil {
+				results[i].Err = fmt.Errorf("failed to associate lun to lungroup: %w", err)
+			}
+		}
+	}
+
+	infos, err := d.getConnectionInfos(ctx, attachment.PortGroup.ID, attachment.Host.ID, lunIDs, opts.CHAP)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection info: %w", err)
+	}
+	for i := range results {
+		if results[i].Err != nil {
+			continue
+		}
+
+		info, ok := infos[results[i].LUNID]
+		if !ok {
+			results[i].Err = fmt.Errorf("LUN (ID: %d) is not associated host (ID: %d)", results[i].LUNID, attachment.Host.ID)
+			continue
+		}
+		if hostLUNIDs != nil && info.HostLUNID != hostLUNIDs[i] {
+			if err := d.DisAssociateLun(ctx, attachment.LunGroup.ID, results[i].LUNID); err != nil {
+				d.Logger.Printf("failed to disassociate lun from lungroup: %v", err)
+			}
+			results[i].Err = fmt.Errorf("%w (requested: %d, actual: %d)", ErrHostLUNIDMismatch, hostLUNIDs[i], info.HostLUNID)
+			continue
+		}
+		results[i].ConnectionInfo = info
+	}
+
+	return results, nil
+}
+
+// DetachVolumes delete mapping of multiple LUNs from host in device.
+// LUNs are disassociated from lungroup in one request, retry each LUN if failed.
+func (d *Device) DetachVolumes(ctx context.Context, hostname string, lunIDs []int) ([]LUNResult, error) {
+	results := newLUNResults(lunIDs)
+	if len(lunIDs) == 0 {
+		return results, nil
+	}
+
+	lungroups, err := d.GetLunGroups(ctx, NewSearchQueryHostname(hostname))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lungroup: %w", err)
+	}
+	if len(lungroups) != 1 {
+		return nil, errors.New("found multiple lungroup in same hostname")
+	}
+	lungroup := lungroups[0]
+
+	associated, err := d.getLunGroupAssociatedLUNIDs(ctx, lungroup.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get associated LUNs: %w", err)
+	}
+
+	var targetIDs []int
+	for i := range results {
+		if !associated[results[i].LUNID] {
+			results[i].Err = fmt.Errorf("LUN (ID: %d) is not associated lungroup (ID: %d)", results[i].LUNID, lungroup.ID)
+			continue
+		}
+		targetIDs = append(targetIDs, results[i].LUNID)
+	}
+	if len(targetIDs) == 0 {
+		return results, nil
+	}
+
+	err = d.DisAssociateLuns(ctx, lungroup.ID, targetIDs)
+	if err != nil {
+		d.Logger.Printf("failed to disassociate luns in one request, retry each lun: %v", err)
+
+		remains, err := d.getLunGroupAssociatedLUNIDs(ctx, lungroup.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get associated LUNs: %w", err)
+		}
+		for i := range results {
+			if results[i].Err != nil || !remains[results[i].LUNID] {
+				continue
+			}
+
+			if err := d.DisAssociateLun(ctx, lungroup.ID, results[i].LUNID); err != nil {
+				results[i].Err = fmt.Errorf("failed to disassociate lun: %w", err)
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// getLunGroupAssociatedLUNIDs return set of LUN ID that associated lungroup
+func (d *Device) getLunGroupAssociatedLUNIDs(ctx context.Context, lungroupID int) (map[int]bool, error) {
+	associated := map[int]bool{}
+
+	luns, err := d.GetLunGroupAssociatedLUNs(ctx, lungroupID)
+	if err != nil {
+		if err == ErrLunNotFound {
+			return associated, nil
+		}
+
+		return nil, err
+	}
+	for _, lun := range luns {
+		associated[lun.ID] = true
+	}
+
+	return associated, nil
+}
+
+func newLUNResults(lunIDs []int) []LUNResult {
+	results := make([]LUNResult, len(lunIDs))
+	for i, lunID := range lunIDs {
+		results[i].LUNID = lunID
+	}
+
+	return results
+}
@@ -0,0 +1,107 @@
+package dorado
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMergeLUNResults(t *testing.T) {
+	errNotFound := fmt.Errorf("failed to get volume information: %w", ErrHyperMetroPairNotFound)
+	localInfo := &ConnectionInfo{HostLUNID: 1}
+	remoteInfo := &ConnectionInfo{HostLUNID: 2}
+
+	results := []VolumeResult{
+		{HyperMetroPairID: "a"},
+		{HyperMetroPairID: "b", Err: errNotFound},
+		{HyperMetroPairID: "c"},
+	}
+	localResults := []LUNResult{
+		{LUNID: 10, ConnectionInfo: localInfo},
+		{LUNID: 30, Err: ErrLunNotFound},
+	}
+	remoteResults := []LUNResult{
+		{LUNID: 20, ConnectionInfo: remoteInfo},
+		{LUNID: 40, ConnectionInfo: remoteInfo},
+	}
+
+	mergeLUNResults(results, localResults, remoteResults, "attach")
+
+	want := &VolumeConnectionInfo{Local: localInfo, Remote: remoteInfo}
+	if results[0].Err != nil || !reflect.DeepEqual(results[0].ConnectionInfo, want) {
+		t.Errorf("mergeLUNResults set %+v, want ConnectionInfo %+v", results[0], want)
+	}
+	if results[1].Err != errNotFound {
+		t.Errorf("mergeLUNResults must not overwrite error, but set %+v", results[1])
+	}
+	if !errors.Is(results[2].Err, ErrLunNotFound) || results[2].ConnectionInfo != nil {
+		t.Errorf("mergeLUNResults must set error of local device, but set %+v", results[2])
+	}
+}
+
+func TestDevice_DetachVolumes(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/lungroup", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": [{"ID": "4", "NAME": "w-cn0001", "TYPE": 256}], "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/lun/associate", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": [{"ID": "10", "TYPE": 11}, {"ID": "11", "TYPE": 11}], "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/lungroup/associate", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		if got := r.URL.Query().Get("ASSOCIATEOBJIDLIST"); got != `["10","11"]` {
+			t.Errorf("ASSOCIATEOBJIDLIST is %s, want %s", got, `["10","11"]`)
+		}
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	results, err := client.LocalDevice.DetachVolumes(context.Background(), "w-cn0001", []int{10, 11, 12})
+	if err != nil {
+		t.Fatalf("DetachVolumes return err: %s", err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("DetachVolumes return %d results, want 3", len(results))
+	}
+	if results[0].Err != nil || results[1].Err != nil {
+		t.Errorf("DetachVolumes return err for associated LUN: %+v", results)
+	}
+	if results[2].Err == nil {
+		t.Errorf("DetachVolumes must return err for not associated LUN: %+v", results[2])
+	}
+}
+
+func TestClient_newVolumeResults(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/HyperMetroPair", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("newVolumeResults must get each HyperMetroPair, but list all")
+	})
+	mux.HandleFunc("/HyperMetroPair/e4c2d1eaf02c0001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "e4c2d1eaf02c0001", "LOCALOBJID": "216", "REMOTEOBJID": "514", "TYPE": 15361}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/HyperMetroPair/e4c2d1eaf02c0002", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 1077674242, "description": "The HyperMetro pair does not exist."}}`)
+	})
+
+	results, localLUNIDs, remoteLUNIDs, err := client.newVolumeResults(context.Background(), []string{"e4c2d1eaf02c0001", "e4c2d1eaf02c0002"})
+	if err != nil {
+		t.Fatalf("newVolumeResults return err: %s", err)
+	}
+	if results[0].Err != nil || results[1].Err == nil {
+		t.Errorf("newVolumeResults return %+v, want error only for second", results)
+	}
+	if !reflect.DeepEqual(localLUNIDs, []int{216}) || !reflect.DeepEqual(remoteLUNIDs, []int{514}) {
+		t.Errorf("newVolumeResults return LUN IDs %v and %v, want [216] and [514]", localLUNIDs, remoteLUNIDs)
+	}
+}
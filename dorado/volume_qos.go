@@ -0,0 +1,92 @@
+package dorado
+
+import (
+	"context"
+	"fmt"
+)
+
+// volumeQoSPolicyName return name of QoS policy that dedicated to LUN
+func volumeQoSPolicyName(lunID int) string {
+	return fmt.Sprintf("volume_qos_%d", lunID)
+}
+
+// isVolumeQoSPolicy return true if policy is dedicated to LUN.
+func isVolumeQoSPolicy(policy *QoSPolicy, lunID int) bool {
+	return policy.NAME == volumeQoSPolicyName(lunID)
+}
+
+// SetLUNQoS set QoS of LUN by dedicated QoS policy.
+// LUN is removed from current QoS policy (dedicated policy is deleted) before new policy is created,
+// because a LUN can belong to only one QoS policy. LUN is not under QoS for a moment.
+// only remove QoS if spec is nil.
+func (d *Device) SetLUNQoS(ctx context.Context, lunID int, spec *QoSSpec) error {
+	if spec != nil {
+		if err := spec.Validate(); err != nil {
+			return fmt.Errorf("failed to validate QoS spec: %w", err)
+		}
+	}
+
+	current, err := d.GetQoSPolicyByLUNID(ctx, lunID)
+	if err != nil && err != ErrQoSPolicyNotFound {
+		return fmt.Errorf("failed to get current QoS policy: %w", err)
+	}
+
+	if current != nil {
+		if isVolumeQoSPolicy(current, lunID) {
+			err = d.DeleteQoSPolicy(ctx, current.ID)
+		} else {
+			err = d.RemoveLUNFromQoSPolicy(ctx, current.ID, lunID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to remove LUN from current QoS policy: %w", err)
+		}
+	}
+	if spec == nil {
+		return nil
+	}
+
+	// create new dedicated policy to clear parameters that not set in spec
+	policy, err := d.CreateQoSPolicy(ctx, volumeQoSPolicyName(lunID), *spec, []int{lunID})
+	if err == nil {
+		err = d.ActivateQoSPolicy(ctx, policy.ID)
+		if err != nil {
+			if err := d.DeleteQoSPolicy(ctx, policy.ID); err != nil {
+				d.Logger.Printf("failed to delete QoS policy: %v", err)
+			}
+		}
+	}
+	if err != nil {
+		if current != nil && !isVolumeQoSPolicy(current, lunID) {
+			// back to shared policy
+			if err := d.AddLUNToQoSPolicy(ctx, current.ID, lunID); err != nil {
+				d.Logger.Printf("failed to add LUN to QoS policy: %v", err)
+			}
+		}
+		return fmt.Errorf("failed to create QoS policy: %w", err)
+	}
+
+	return nil
+}
+
+// SetVolumeQoS set QoS of both LUNs in HyperMetroPair. remove QoS if spec is nil.
+func (c *Client) SetVolumeQoS(ctx context.Context, hyperMetroPairID string, spec *QoSSpec) error {
+	if spec != nil {
+		if err := spec.Validate(); err != nil {
+			return fmt.Errorf("failed to validate QoS spec: %w", err)
+		}
+	}
+
+	hmp, err := c.GetHyperMetroPair(ctx, hyperMetroPairID)
+	if err != nil {
+		return fmt.Errorf("failed to get HyperMetroPair: %w", err)
+	}
+
+	return c.runBothDevices(ctx, fmt.Sprintf("set QoS of HyperMetroPair (ID: %s)", hmp.ID),
+		func(ctx context.Context) error {
+			return c.LocalDevice.SetLUNQoS(ctx, hmp.LOCALOBJID, spec)
+		},
+		func(ctx context.Context) error {
+			return c.RemoteDevice.SetLUNQoS(ctx, hmp.REMOTEOBJID, spec)
+		},
+	)
+}
@@ -0,0 +1,78 @@
+package dorado
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestClient_SetVolumeQoS(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	var called []string
+	record := func(r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		called = append(called, r.Method+" "+r.URL.Path)
+	}
+
+	mux.HandleFunc("/HyperMetroPair/e4c2d1eaf02c0001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "e4c2d1eaf02c0001", "LOCALOBJID": "216", "REMOTEOBJID": "514", "TYPE": 15361}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/lun/216", func(w http.ResponseWriter, r *http.Request) {
+		// local LUN has dedicated policy
+		fmt.Fprint(w, `{"data": {"ID": "216", "IOCLASSID": "1", "TYPE": 11}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/lun/514", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": {"ID": "514", "IOCLASSID": "", "TYPE": 11}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/ioclass/1", func(w http.ResponseWriter, r *http.Request) {
+		record(r)
+		fmt.Fprint(w, `{"data": {"ID": "1", "NAME": "volume_qos_216", "ENABLESTATUS": "true", "LUNLIST": "[\"216\"]", "TYPE": 230}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/ioclass/active/1", func(w http.ResponseWriter, r *http.Request) {
+		record(r)
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/ioclass", func(w http.ResponseWriter, r *http.Request) {
+		var param map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
+			t.Fatalf("failed to decode request: %s", err)
+		}
+		mu.Lock()
+		called = append(called, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, param["NAME"]))
+		mu.Unlock()
+		fmt.Fprint(w, `{"data": {"ID": "2", "ENABLESTATUS": "false", "TYPE": 230}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/ioclass/active/2", func(w http.ResponseWriter, r *http.Request) {
+		record(r)
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	if err := client.SetVolumeQoS(context.Background(), "e4c2d1eaf02c0001", &QoSSpec{MaxIOPS: 1000}); err != nil {
+		t.Fatalf("SetVolumeQoS return err: %s", err)
+	}
+
+	want := []string{
+		// local: replace dedicated policy
+		"GET /ioclass/1",
+		"GET /ioclass/1",
+		"PUT /ioclass/active/1",
+		"DELETE /ioclass/1",
+		"POST /ioclass volume_qos_216",
+		"PUT /ioclass/active/2",
+		// remote: create policy
+		"POST /ioclass volume_qos_514",
+		"PUT /ioclass/active/2",
+	}
+	if !reflect.DeepEqual(called, want) {
+		t.Errorf("SetVolumeQoS called %v, want %v", called, want)
+	}
+}
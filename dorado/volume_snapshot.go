@@ -0,0 +1,520 @@
+package dorado
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	uuid "github.com/satori/go.uuid"
+	"golang.org/x/sync/errgroup"
+)
+
+// VolumeSnapshotMode is mode of taking snapshot of volume (= HyperMetroPair)
+type VolumeSnapshotMode int
+
+// VolumeSnapshotModes
+const (
+	// VolumeSnapshotBothSides take snapshots of LUNs in both devices.
+	VolumeSnapshotBothSides VolumeSnapshotMode = iota
+	// VolumeSnapshotPreferredSide take snapshot of LUN in preferred (= primary) device only.
+	VolumeSnapshotPreferredSide
+)
+
+// VolumeSnapshot is snapshot of volume (= HyperMetroPair).
+// Local or Remote is nil if snapshot is not taken in the device.
+type VolumeSnapshot struct {
+	Name             string
+	HyperMetroPairID string
+
+	Local  *Snapshot
+	Remote *Snapshot
+}
+
+// Snapshot return snapshot in side of device.
+func (vs *VolumeSnapshot) Snapshot(side DeviceSide) *Snapshot {
+	switch side {
+	case SideLocal:
+		return vs.Local
+	case SideRemote:
+		return vs.Remote
+	default:
+		return nil
+	}
+}
+
+func (vs *VolumeSnapshot) set(side DeviceSide, snapshot *Snapshot) {
+	switch side {
+	case SideLocal:
+		vs.Local = snapshot
+	case SideRemote:
+		vs.Remote = snapshot
+	}
+}
+
+// preferredSide return side of device that is preferred (= primary) in HyperMetroPair.
+func (hmp *HyperMetroPair) preferredSide() DeviceSide {
+	if hmp.ISPRIMARY == "false" {
+		return SideRemote
+	}
+	return SideLocal
+}
+
+// lunID return LUN ID of HyperMetroPair in side of device.
+func (hmp *HyperMetroPair) lunID(side DeviceSide) int {
+	if side == SideRemote {
+		return hmp.REMOTEOBJID
+	}
+	return hmp.LOCALOBJID
+}
+
+// volumeSnapshotSides return sides of device that take snapshot by VolumeSnapshotMode.
+func (c *Client) volumeSnapshotSides(hmp *HyperMetroPair) []DeviceSide {
+	if c.VolumeSnapshotMode == VolumeSnapshotPreferredSide {
+		return []DeviceSide{hmp.preferredSide()}
+	}
+	return []DeviceSide{SideLocal, SideRemote}
+}
+
+// CreateVolumeSnapshot create snapshots of volume by VolumeSnapshotMode and activate them.
+// snapshots in both devices are activated at same time, pair must be synchronized.
+func (c *Client) CreateVolumeSnapshot(ctx context.Context, hyperMetroPairID string, name uuid.UUID) (*VolumeSnapshot, error) {
+	return c.CreateVolumeSnapshotByName(ctx, hyperMetroPairID, EncodeSnapshotName(name))
+}
+
+// CreateVolumeSnapshotByName is same as CreateVolumeSnapshot, but snapshots are named as it is.
+func (c *Client) CreateVolumeSnapshotByName(ctx context.Context, hyperMetroPairID, name string) (*VolumeSnapshot, error) {
+	if err := c.requireBothDevices(ctx); err != nil {
+		return nil, err
+	}
+
+	hmp, err := c.GetHyperMetroPair(ctx, hyperMetroPairID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HyperMetroPair: %w", err)
+	}
+
+	sides := c.volumeSnapshotSides(hmp)
+	if state := hmp.State(); len(sides) > 1 && state != HyperMetroPairStateNormal {
+		return nil, fmt.Errorf("can't take snapshots of both devices while HyperMetroPair is %s: %w", state, ErrHyperMetroPairUnhealthy)
+	}
+
+	vs := &VolumeSnapshot{
+		Name:             name,
+		HyperMetroPairID: hmp.ID,
+	}
+	defer func() {
+		if err != nil {
+			c.deleteVolumeSnapshot(ctx, vs)
+		}
+	}()
+
+	description := fmt.Sprintf("snapshot of HyperMetroPair %s", hmp.ID)
+	for _, side := range sides {
+		var snapshot *Snapshot
+		snapshot, err = c.device(side).CreateSnapshotByNameWithWait(ctx, hmp.lunID(side), name, description)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create snapshot in %s: %w", side, err)
+		}
+		vs.set(side, snapshot)
+	}
+
+	eg := errgroup.Group{}
+	for _, side := range sides {
+		side := side
+		eg.Go(func() error {
+			if err := c.device(side).ActivateSnapshot(ctx, vs.Snapshot(side).ID); err != nil {
+				return fmt.Errorf("failed to activate snapshot in %s: %w", side, err)
+			}
+			return nil
+		})
+	}
+	if err = eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	for _, side := range sides {
+		var snapshot *Snapshot
+		snapshot, err = c.device(side).GetSnapshot(ctx, vs.Snapshot(side).ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get snapshot in %s: %w", side, err)
+		}
+		vs.set(side, snapshot)
+	}
+
+	return vs, nil
+}
+
+// ListVolumeSnapshots get snapshots of volume.
+// in degraded mode, return snapshots in reachable device only.
+func (c *Client) ListVolumeSnapshots(ctx context.Context, hyperMetroPairID string) ([]VolumeSnapshot, error) {
+	hmp, err := c.GetHyperMetroPair(ctx, hyperMetroPairID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HyperMetroPair: %w", err)
+	}
+
+	var names []string
+	byName := map[string]*VolumeSnapshot{}
+	for _, side := range []DeviceSide{SideLocal, SideRemote} {
+		if side == c.UnreachableSide() {
+			continue
+		}
+
+		snapshots, err := c.device(side).GetSnapshots(ctx, &SearchQuery{
+			Filter: ToFilter("PARENTID", strconv.Itoa(hmp.lunID(side))),
+		})
+		if err == ErrSnapshotNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get snapshots in %s: %w", side, err)
+		}
+
+		for i := range snapshots {
+			snapshot := snapshots[i]
+			if snapshot.PARENTTYPE != TypeLUN {
+				continue
+			}
+
+			vs, ok := byName[snapshot.NAME]
+			if !ok {
+				vs = &VolumeSnapshot{
+					Name:             snapshot.NAME,
+					HyperMetroPairID: hmp.ID,
+				}
+				byName[snapshot.NAME] = vs
+				names = append(names, snapshot.NAME)
+			}
+			vs.set(side, &snapshot)
+		}
+	}
+
+	if len(names) == 0 {
+		return nil, ErrSnapshotNotFound
+	}
+
+	var volumeSnapshots []VolumeSnapshot
+	for _, name := range names {
+		volumeSnapshots = append(volumeSnapshots, *byName[name])
+	}
+
+	return volumeSnapshots, nil
+}
+
+// GetVolumeSnapshot get snapshot of volume by name.
+func (c *Client) GetVolumeSnapshot(ctx context.Context, hyperMetroPairID string, name uuid.UUID) (*VolumeSnapshot, error) {
+	return c.GetVolumeSnapshotByName(ctx, hyperMetroPairID, EncodeSnapshotName(name))
+}
+
+// GetVolumeSnapshotByName get snapshot of volume by name as it is.
+func (c *Client) GetVolumeSnapshotByName(ctx context.Context, hyperMetroPairID, name string) (*VolumeSnapshot, error) {
+	volumeSnapshots, err := c.ListVolumeSnapshots(ctx, hyperMetroPairID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, vs := range volumeSnapshots {
+		if vs.Name == name {
+			return &vs, nil
+		}
+	}
+
+	return nil, ErrSnapshotNotFound
+}
+
+// DeleteVolumeSnapshot stop and delete snapshots of volume in both devices.
+func (c *Client) DeleteVolumeSnapshot(ctx context.Context, hyperMetroPairID string, name uuid.UUID) error {
+	return c.DeleteVolumeSnapshotByName(ctx, hyperMetroPairID, EncodeSnapshotName(name))
+}
+
+// DeleteVolumeSnapshotByName stop and delete snapshots of volume by name as it is.
+func (c *Client) DeleteVolumeSnapshotByName(ctx context.Context, hyperMetroPairID, name string) error {
+	if err := c.requireBothDevices(ctx); err != nil {
+		return err
+	}
+
+	vs, err := c.GetVolumeSnapshotByName(ctx, hyperMetroPairID, name)
+	if err != nil {
+		return fmt.Errorf("failed to get volume snapshot: %w", err)
+	}
+
+	for _, side := range []DeviceSide{SideLocal, SideRemote} {
+		snapshot := vs.Snapshot(side)
+		if snapshot == nil {
+			continue
+		}
+
+		if err := c.device(side).StopAndDeleteSnapshot(ctx, snapshot); err != nil {
+			return fmt.Errorf("failed to delete snapshot in %s: %w", side, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteVolumeSnapshot delete snapshots of volume without error, for cleanup.
+func (c *Client) deleteVolumeSnapshot(ctx context.Context, vs *VolumeSnapshot) {
+	for _, side := range []DeviceSide{SideLocal, SideRemote} {
+		snapshot := vs.Snapshot(side)
+		if snapshot == nil {
+			continue
+		}
+
+		if err := c.device(side).StopAndDeleteSnapshot(ctx, snapshot); err != nil {
+			c.Logger.Printf("failed to delete snapshot in %s: %v\n", side, err)
+		}
+	}
+}
+
+// RestoreVolumeSnapshot restore volume in place from snapshot by highest speed.
+// see RollbackVolumeSnapshot for details.
+func (c *Client) RestoreVolumeSnapshot(ctx context.Context, hyperMetroPairID string, name uuid.UUID) error {
+	return c.RollbackVolumeSnapshot(ctx, hyperMetroPairID, name, SpeedHighest, nil)
+}
+
+// VolumeSnapshotGroup is snapshot consistency groups over volumes in both devices.
+type VolumeSnapshotGroup struct {
+	Local  *SnapshotConsistencyGroup
+	Remote *SnapshotConsistencyGroup
+}
+
+// Group return snapshot consistency group in side of device.
+func (vsg *VolumeSnapshotGroup) Group(side DeviceSide) *SnapshotConsistencyGroup {
+	switch side {
+	case SideLocal:
+		return vsg.Local
+	case SideRemote:
+		return vsg.Remote
+	default:
+		return nil
+	}
+}
+
+func (vsg *VolumeSnapshotGroup) set(side DeviceSide, group *SnapshotConsistencyGroup) {
+	switch side {
+	case SideLocal:
+		vsg.Local = group
+	case SideRemote:
+		vsg.Remote = group
+	}
+}
+
+// snapshotOf return snapshot of LUN in group.
+func (vsg *VolumeSnapshotGroup) snapshotOf(ctx context.Context, d *Device, side DeviceSide, lunID int) (*Snapshot, error) {
+	group := vsg.Group(side)
+	if group == nil {
+		return nil, fmt.Errorf("snapshot consistency group in %s is not set", side)
+	}
+
+	snapshots, err := d.GetSnapshotConsistencyGroupMembers(ctx, group.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshots in group (ID: %d): %w", group.ID, err)
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.SOURCELUNID == strconv.Itoa(lunID) {
+			return &snapshot, nil
+		}
+	}
+
+	return nil, fmt.Errorf("snapshot of LUN (ID: %d) is not in group (ID: %d): %w", lunID, group.ID, ErrSnapshotNotFound)
+}
+
+// CreateVolumeSnapshotGroup create snapshot consistency groups over volumes in both devices and activate them.
+// all volumes are frozen at same time, pairs must be synchronized.
+func (c *Client) CreateVolumeSnapshotGroup(ctx context.Context, name string, hyperMetroPairIDs []string) (*VolumeSnapshotGroup, error) {
+	if err := c.requireBothDevices(ctx); err != nil {
+		return nil, err
+	}
+
+	lunIDs := map[DeviceSide][]int{}
+	for _, id := range hyperMetroPairIDs {
+		hmp, err := c.GetHyperMetroPair(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get HyperMetroPair: %w", err)
+		}
+		if state := hmp.State(); state != HyperMetroPairStateNormal {
+			return nil, fmt.Errorf("can't take snapshots of both devices while HyperMetroPair (ID: %s) is %s: %w", hmp.ID, state, ErrHyperMetroPairUnhealthy)
+		}
+
+		for _, side := range []DeviceSide{SideLocal, SideRemote} {
+			lunIDs[side] = append(lunIDs[side], hmp.lunID(side))
+		}
+	}
+
+	var err error
+	vsg := &VolumeSnapshotGroup{}
+	defer func() {
+		if err != nil {
+			c.deleteVolumeSnapshotGroup(ctx, vsg)
+		}
+	}()
+
+	for _, side := range []DeviceSide{SideLocal, SideRemote} {
+		var group *SnapshotConsistencyGroup
+		group, err = c.device(side).CreateSnapshotConsistencyGroup(ctx, name, lunIDs[side])
+		if err != nil {
+			return nil, fmt.Errorf("failed to create snapshot consistency group in %s: %w", side, err)
+		}
+		vsg.set(side, group)
+	}
+
+	eg := errgroup.Group{}
+	for _, side := range []DeviceSide{SideLocal, SideRemote} {
+		side := side
+		eg.Go(func() error {
+			if err := c.device(side).ActivateSnapshotConsistencyGroup(ctx, vsg.Group(side).ID); err != nil {
+				return fmt.Errorf("failed to activate snapshot consistency group in %s: %w", side, err)
+			}
+			return nil
+		})
+	}
+	if err = eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	for _, side := range []DeviceSide{SideLocal, SideRemote} {
+		var group *SnapshotConsistencyGroup
+		group, err = c.device(side).GetSnapshotConsistencyGroup(ctx, vsg.Group(side).ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get snapshot consistency group in %s: %w", side, err)
+		}
+		vsg.set(side, group)
+	}
+
+	return vsg, nil
+}
+
+// DeleteVolumeSnapshotGroup stop and delete snapshot consistency groups in both devices.
+func (c *Client) DeleteVolumeSnapshotGroup(ctx context.Context, vsg *VolumeSnapshotGroup) error {
+	if err := c.requireBothDevices(ctx); err != nil {
+		return err
+	}
+
+	for _, side := range []DeviceSide{SideLocal, SideRemote} {
+		group := vsg.Group(side)
+		if group == nil {
+			continue
+		}
+
+		if err := c.device(side).DeleteSnapshotConsistencyGroup(ctx, group.ID); err != nil {
+			return fmt.Errorf("failed to delete snapshot consistency group in %s: %w", side, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteVolumeSnapshotGroup delete snapshot consistency groups without error, for cleanup.
+func (c *Client) deleteVolumeSnapshotGroup(ctx context.Context, vsg *VolumeSnapshotGroup) {
+	for _, side := range []DeviceSide{SideLocal, SideRemote} {
+		group := vsg.Group(side)
+		if group == nil {
+			continue
+		}
+
+		if err := c.device(side).DeleteSnapshotConsistencyGroup(ctx, group.ID); err != nil {
+			c.Logger.Printf("failed to delete snapshot consistency group in %s: %v\n", side, err)
+		}
+	}
+}
+
+// CreateVolumeFromSnapshot create HyperMetroPair that copy from snapshot of volume.
+// LUN is copied from snapshot in one device only (local device if it has snapshot), other LUN is created blank and synchronized from it.
+// use Device.CreateLUNFromSnapshot for local mode.
+func (c *Client) CreateVolumeFromSnapshot(ctx context.Context, name uuid.UUID, capacityGB int, storagePoolName, hyperMetroDomainID string, source *VolumeSnapshot, opts *VolumeOption) (*HyperMetroPair, error) {
+	if err := c.requireBothDevices(ctx); err != nil {
+		return nil, err
+	}
+	if source == nil || (source.Local == nil && source.Remote == nil) {
+		return nil, fmt.Errorf("snapshot of source is not set: %w", ErrSnapshotNotFound)
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	copySide := SideLocal
+	if source.Local == nil {
+		copySide = SideRemote
+	}
+
+	lunIDs := map[DeviceSide]int{}
+	var mu sync.Mutex
+	eg := errgroup.Group{}
+	for _, side := range []DeviceSide{SideLocal, SideRemote} {
+		side := side
+		eg.Go(func() error {
+			d := c.device(side)
+
+			var lun *LUN
+			var err error
+			if side == copySide {
+				lun, err = d.CreateLUNFromSnapshotWithSpec(ctx, source.Snapshot(side).ID, name, capacityGB, storagePoolName, opts.lunSpec())
+			} else {
+				lun, err = d.CreateLUNWithSpecWithWait(ctx, name, capacityGB, storagePoolName, opts.lunSpec())
+			}
+			if err != nil {
+				return fmt.Errorf("failed to create lun in %s: %w", side, err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			lunIDs[side] = lun.ID
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		c.deleteLUNs(ctx, lunIDs)
+		return nil, fmt.Errorf("failed to create lun from snapshot: %w", err)
+	}
+
+	hyperMetroPair, err := c.CreateHyperMetroPair(ctx, hyperMetroDomainID, lunIDs[SideLocal], lunIDs[SideRemote])
+	if err != nil {
+		c.deleteLUNs(ctx, lunIDs)
+		return nil, fmt.Errorf("failed to create HyperMetroPair from snapshot: %w", err)
+	}
+
+	// pair is created without first sync, sync from device that copied snapshot
+	direction := SyncDirectionLocalToRemote
+	if copySide == SideRemote {
+		direction = SyncDirectionRemoteToLocal
+	}
+	if err := c.SetHyperMetroPairSyncDirection(ctx, hyperMetroPair.ID, direction); err != nil {
+		c.deleteHyperMetroPairWithLUNs(ctx, hyperMetroPair.ID, lunIDs)
+		return nil, fmt.Errorf("failed to set sync direction: %w", err)
+	}
+	if err := c.SyncHyperMetroPair(ctx, hyperMetroPair.ID); err != nil {
+		c.deleteHyperMetroPairWithLUNs(ctx, hyperMetroPair.ID, lunIDs)
+		return nil, fmt.Errorf("failed to sync HyperMetroPair: %w", err)
+	}
+
+	if err := c.setQoS(ctx, hyperMetroPair, opts); err != nil {
+		return nil, fmt.Errorf("failed to set QoS: %w", err)
+	}
+
+	hyperMetroPair, err = c.waitSynced(ctx, hyperMetroPair, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait HyperMetroPair synced: %w", err)
+	}
+
+	return hyperMetroPair, nil
+}
+
+// deleteLUNs delete LUNs in each device without error, for cleanup.
+func (c *Client) deleteLUNs(ctx context.Context, lunIDs map[DeviceSide]int) {
+	for side, lunID := range lunIDs {
+		if err := c.device(side).DeleteLUN(ctx, lunID); err != nil {
+			c.Logger.Printf("failed to delete LUN in %s: %v", side, err)
+		}
+	}
+}
+
+// deleteHyperMetroPairWithLUNs suspend and delete HyperMetroPair and LUNs without error, for cleanup.
+func (c *Client) deleteHyperMetroPairWithLUNs(ctx context.Context, hyperMetroPairID string, lunIDs map[DeviceSide]int) {
+	if err := c.SuspendHyperMetroPair(ctx, hyperMetroPairID); err != nil {
+		c.Logger.Printf("failed to suspend HyperMetroPair: %v", err)
+	}
+	if err := c.DeleteHyperMetroPair(ctx, hyperMetroPairID); err != nil {
+		c.Logger.Printf("failed to delete HyperMetroPair: %v", err)
+		return
+	}
+	c.deleteLUNs(ctx, lunIDs)
+}
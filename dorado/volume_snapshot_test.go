@@ -0,0 +1,162 @@
+package dorado
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const testVolumeSnapshotPair = `{"data": {"ID": "e4c2d1eaf02c0001", "ISPRIMARY": "true", "LOCALOBJID": "514", "REMOTEOBJID": "216", "HEALTHSTATUS": "1", "RUNNINGSTATUS": "1", "TYPE": 15361}, "error": {"code": 0, "description": "0"}}`
+
+func TestClient_ListVolumeSnapshots(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/HyperMetroPair/e4c2d1eaf02c0001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, testVolumeSnapshotPair)
+	})
+	mux.HandleFunc("/snapshot", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("filter") {
+		case "PARENTID::514":
+			fmt.Fprint(w, `{"data": [{"ID": "1", "NAME": "snap-a", "PARENTID": "514", "PARENTTYPE": 11, "RUNNINGSTATUS": "43", "TYPE": 27}, {"ID": "2", "NAME": "snap-b", "PARENTID": "514", "PARENTTYPE": 11, "RUNNINGSTATUS": "43", "TYPE": 27}], "error": {"code": 0, "description": "0"}}`)
+		case "PARENTID::216":
+			fmt.Fprint(w, `{"data": [{"ID": "11", "NAME": "snap-a", "PARENTID": "216", "PARENTTYPE": 11, "RUNNINGSTATUS": "43", "TYPE": 27}], "error": {"code": 0, "description": "0"}}`)
+		default:
+			t.Errorf("unexpected filter: %s", r.URL.Query().Get("filter"))
+		}
+	})
+
+	volumeSnapshots, err := client.ListVolumeSnapshots(context.Background(), "e4c2d1eaf02c0001")
+	if err != nil {
+		t.Fatalf("ListVolumeSnapshots return err: %s", err)
+	}
+
+	want := []VolumeSnapshot{
+		{
+			Name:             "snap-a",
+			HyperMetroPairID: "e4c2d1eaf02c0001",
+			Local:            &Snapshot{ID: 1, NAME: "snap-a", PARENTID: 514, PARENTTYPE: TypeLUN, RUNNINGSTATUS: "43", TYPE: TypeSnapshot},
+			Remote:           &Snapshot{ID: 11, NAME: "snap-a", PARENTID: 216, PARENTTYPE: TypeLUN, RUNNINGSTATUS: "43", TYPE: TypeSnapshot},
+		},
+		{
+			Name:             "snap-b",
+			HyperMetroPairID: "e4c2d1eaf02c0001",
+			Local:            &Snapshot{ID: 2, NAME: "snap-b", PARENTID: 514, PARENTTYPE: TypeLUN, RUNNINGSTATUS: "43", TYPE: TypeSnapshot},
+		},
+	}
+	if !reflect.DeepEqual(volumeSnapshots, want) {
+		t.Errorf("ListVolumeSnapshots return %+v, want %+v", volumeSnapshots, want)
+	}
+}
+
+func TestClient_CreateVolumeSnapshot_PreferredSide(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.VolumeSnapshotMode = VolumeSnapshotPreferredSide
+
+	var created, activated int
+	mux.HandleFunc("/HyperMetroPair/e4c2d1eaf02c0001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, testVolumeSnapshotPair)
+	})
+	mux.HandleFunc("/snapshot", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var param map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
+			t.Fatalf("failed to decode request: %s", err)
+		}
+		if param["PARENTID"] != "514" {
+			t.Errorf("CreateSnapshot PARENTID = %s, want 514", param["PARENTID"])
+		}
+		created++
+		fmt.Fprint(w, `{"data": {"ID": "1", "NAME": "snap", "PARENTID": "514", "PARENTTYPE": 11, "HEALTHSTATUS": "1", "RUNNINGSTATUS": "45", "TYPE": 27}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/snapshot/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		status := "45"
+		if activated > 0 {
+			status = "43"
+		}
+		fmt.Fprintf(w, `{"data": {"ID": "1", "NAME": "snap", "PARENTID": "514", "PARENTTYPE": 11, "HEALTHSTATUS": "1", "RUNNINGSTATUS": "%s", "TYPE": 27}, "error": {"code": 0, "description": "0"}}`, status)
+	})
+	mux.HandleFunc("/snapshot/activate", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		activated++
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	vs, err := client.CreateVolumeSnapshot(context.Background(), "e4c2d1eaf02c0001", uuid.UUID{})
+	if err != nil {
+		t.Fatalf("CreateVolumeSnapshot return err: %s", err)
+	}
+	if created != 1 || activated != 1 {
+		t.Errorf("CreateVolumeSnapshot created %d and activated %d snapshots, want 1 and 1", created, activated)
+	}
+	if vs.Local == nil || vs.Remote != nil {
+		t.Fatalf("CreateVolumeSnapshot return %+v, want snapshot in Local Device only", vs)
+	}
+	if vs.Local.RUNNINGSTATUS != "43" {
+		t.Errorf("CreateVolumeSnapshot return RUNNINGSTATUS %s, want 43", vs.Local.RUNNINGSTATUS)
+	}
+}
+
+func TestClient_CreateVolumeSnapshot_NotSynchronized(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/HyperMetroPair/e4c2d1eaf02c0001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "e4c2d1eaf02c0001", "ISPRIMARY": "true", "LOCALOBJID": "514", "REMOTEOBJID": "216", "HEALTHSTATUS": "1", "RUNNINGSTATUS": "41", "TYPE": 15361}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	_, err := client.CreateVolumeSnapshot(context.Background(), "e4c2d1eaf02c0001", uuid.UUID{})
+	if !errors.Is(err, ErrHyperMetroPairUnhealthy) {
+		t.Errorf("CreateVolumeSnapshot return err: %v, want %v", err, ErrHyperMetroPairUnhealthy)
+	}
+}
+
+func TestClient_CreateVolumeFromSnapshot_Cleanup(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	remoteMux, remoteTeardown := setupRemote(client)
+	defer remoteTeardown()
+
+	mux.HandleFunc("/snapshot/12", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": {"ID": "12", "RUNNINGSTATUS": "45", "USERCAPACITY": "2097152", "TYPE": 27}, "error": {"code": 0, "description": "0"}}`)
+	})
+	remoteMux.HandleFunc("/storagepool", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": [{"ID": "0", "NAME": "pool"}], "error": {"code": 0, "description": "0"}}`)
+	})
+	remoteMux.HandleFunc("/lun", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"data": {"ID": "6", "TYPE": 11}, "error": {"code": 0, "description": "0"}}`)
+	})
+	var deleted bool
+	remoteMux.HandleFunc("/lun/6", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"data": {"ID": "6", "HEALTHSTATUS": "1", "RUNNINGSTATUS": "27", "ISCLONE": "false", "TYPE": 11}, "error": {"code": 0, "description": "0"}}`)
+		case "DELETE":
+			deleted = true
+			fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+		}
+	})
+
+	source := &VolumeSnapshot{Local: &Snapshot{ID: 12}}
+	if _, err := client.CreateVolumeFromSnapshot(context.Background(), uuid.UUID{}, 1, "pool", "1", source, nil); err == nil {
+		t.Fatalf("CreateVolumeFromSnapshot must return err if failed to copy snapshot")
+	}
+	if !deleted {
+		t.Errorf("CreateVolumeFromSnapshot must delete LUN created in remote device")
+	}
+}
@@ -0,0 +1,163 @@
+// Package dr provides declarative runbooks of disaster recovery (switchover, failover and failback)
+// for HyperMetroPairs and replication pairs.
+package dr
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+
+	"github.com/lovi-cloud/go-dorado-sdk/dorado"
+)
+
+// Error Values
+var (
+	ErrPreflightFailed = errors.New("pre-flight check is failed")
+	ErrStepFailed      = errors.New("step is failed")
+	ErrClientRequired  = errors.New("client is required")
+)
+
+// Kind is kind of runbook
+type Kind int
+
+// Kinds
+const (
+	// PlannedSwitchover move primary to other site while both sites are running.
+	PlannedSwitchover Kind = iota + 1
+	// UnplannedFailover start service in surviving site while other site is down.
+	// HyperMetro: surviving site is LocalDevice of dorado.Client.
+	// Replication: surviving site is SecondaryDevice of dorado.ReplicationClient.
+	UnplannedFailover
+	// Failback re-sync data from surviving site after failed site is recovered.
+	// run PlannedSwitchover after synced to move primary back to recovered site.
+	Failback
+)
+
+// String return name of kind
+func (k Kind) String() string {
+	switch k {
+	case PlannedSwitchover:
+		return "planned switchover"
+	case UnplannedFailover:
+		return "unplanned failover"
+	case Failback:
+		return "failback"
+	default:
+		return fmt.Sprintf("unknown (%d)", int(k))
+	}
+}
+
+// TargetKind is kind of object that operated by runbook
+type TargetKind int
+
+// TargetKinds
+const (
+	TargetHyperMetroPair TargetKind = iota + 1
+	TargetHyperMetroConsistencyGroup
+	TargetReplicationPair
+	TargetReplicationConsistencyGroup
+)
+
+// String return name of target kind
+func (k TargetKind) String() string {
+	switch k {
+	case TargetHyperMetroPair:
+		return "HyperMetroPair"
+	case TargetHyperMetroConsistencyGroup:
+		return "HyperMetro consistency group"
+	case TargetReplicationPair:
+		return "replication pair"
+	case TargetReplicationConsistencyGroup:
+		return "replication consistency group"
+	default:
+		return fmt.Sprintf("unknown (%d)", int(k))
+	}
+}
+
+// Target is object that operated by runbook
+type Target struct {
+	Kind TargetKind
+	ID   string
+}
+
+// String return readable target
+func (t Target) String() string {
+	return fmt.Sprintf("%s (ID: %s)", t.Kind, t.ID)
+}
+
+// Runbook is declarative definition of DR operation.
+type Runbook struct {
+	Name string
+	Kind Kind
+
+	HyperMetroPairIDs              []string
+	HyperMetroConsistencyGroupIDs  []string
+	ReplicationPairIDs             []string
+	ReplicationConsistencyGroupIDs []string
+
+	// WaitSynced is used to wait HyperMetroPairs and consistency groups synced in Failback.
+	// use default values of dorado.WaitSyncOption if nil.
+	WaitSynced *dorado.WaitSyncOption
+}
+
+func (rb *Runbook) targets() []Target {
+	var targets []Target
+	for _, id := range rb.HyperMetroPairIDs {
+		targets = append(targets, Target{Kind: TargetHyperMetroPair, ID: id})
+	}
+	for _, id := range rb.HyperMetroConsistencyGroupIDs {
+		targets = append(targets, Target{Kind: TargetHyperMetroConsistencyGroup, ID: id})
+	}
+	for _, id := range rb.ReplicationPairIDs {
+		targets = append(targets, Target{Kind: TargetReplicationPair, ID: id})
+	}
+	for _, id := range rb.ReplicationConsistencyGroupIDs {
+		targets = append(targets, Target{Kind: TargetReplicationConsistencyGroup, ID: id})
+	}
+
+	return targets
+}
+
+// Runner run runbooks.
+type Runner struct {
+	// HyperMetro is required if runbook has HyperMetro targets.
+	HyperMetro *dorado.Client
+	// Replication is required if runbook has replication targets.
+	Replication *dorado.ReplicationClient
+
+	Logger *log.Logger
+}
+
+// NewRunner create Runner. hyperMetro or replication can be nil if not used.
+func NewRunner(hyperMetro *dorado.Client, replication *dorado.ReplicationClient, logger *log.Logger) *Runner {
+	if logger == nil {
+		logger = log.New(ioutil.Discard, "", log.LstdFlags)
+	}
+
+	return &Runner{
+		HyperMetro:  hyperMetro,
+		Replication: replication,
+		Logger:      logger,
+	}
+}
+
+func (r *Runner) validate(rb Runbook) error {
+	switch rb.Kind {
+	case PlannedSwitchover, UnplannedFailover, Failback:
+	default:
+		return fmt.Errorf("invalid runbook kind: %s", rb.Kind)
+	}
+
+	if len(rb.targets()) == 0 {
+		return errors.New("runbook has no target")
+	}
+	if (len(rb.HyperMetroPairIDs) > 0 || len(rb.HyperMetroConsistencyGroupIDs) > 0) && r.HyperMetro == nil {
+		return fmt.Errorf("HyperMetro %w", ErrClientRequired)
+	}
+	if (len(rb.ReplicationPairIDs) > 0 || len(rb.ReplicationConsistencyGroupIDs) > 0) && r.Replication == nil {
+		return fmt.Errorf("replication %w", ErrClientRequired)
+	}
+
+	return nil
+}
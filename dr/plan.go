@@ -0,0 +1,323 @@
+package dr
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lovi-cloud/go-dorado-sdk/dorado"
+)
+
+// Check is result of pre-flight check
+type Check struct {
+	Target Target
+	// Err is nil if check is passed.
+	Err error
+}
+
+// Step is one operation of runbook
+type Step struct {
+	Target Target
+	Name   string
+
+	run func(ctx context.Context) error
+}
+
+// Plan is checks and ordered steps of runbook
+type Plan struct {
+	Runbook Runbook
+	Checks  []Check
+	Steps   []Step
+}
+
+// Ready return true if all pre-flight checks are passed.
+func (p *Plan) Ready() bool {
+	for _, c := range p.Checks {
+		if c.Err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String return readable plan
+func (p *Plan) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "runbook: %s (%s)\n", p.Runbook.Name, p.Runbook.Kind)
+	b.WriteString("pre-flight checks:\n")
+	for _, c := range p.Checks {
+		if c.Err != nil {
+			fmt.Fprintf(&b, "  NG %s: %s\n", c.Target, c.Err)
+			continue
+		}
+		fmt.Fprintf(&b, "  OK %s\n", c.Target)
+	}
+	b.WriteString("steps:\n")
+	for i, s := range p.Steps {
+		fmt.Fprintf(&b, "  %d. %s: %s\n", i+1, s.Target, s.Name)
+	}
+
+	return b.String()
+}
+
+// Plan run pre-flight checks and create steps of runbook. Plan not change any objects.
+func (r *Runner) Plan(ctx context.Context, rb Runbook) (*Plan, error) {
+	if err := r.validate(rb); err != nil {
+		return nil, fmt.Errorf("invalid runbook: %w", err)
+	}
+
+	plan := &Plan{
+		Runbook: rb,
+	}
+	for _, target := range rb.targets() {
+		plan.Checks = append(plan.Checks, Check{
+			Target: target,
+			Err:    r.check(ctx, rb, target),
+		})
+		plan.Steps = append(plan.Steps, r.steps(rb, target)...)
+	}
+
+	return plan, nil
+}
+
+func (r *Runner) check(ctx context.Context, rb Runbook, target Target) error {
+	switch target.Kind {
+	case TargetHyperMetroPair:
+		hmp, err := r.HyperMetro.GetHyperMetroPair(ctx, target.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get HyperMetroPair: %w", err)
+		}
+		return checkHyperMetro(rb.Kind, hmp.HEALTHSTATUS, hmp.State())
+	case TargetHyperMetroConsistencyGroup:
+		group, err := r.HyperMetro.GetHyperMetroConsistencyGroup(ctx, target.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get HyperMetro consistency group: %w", err)
+		}
+		status, _ := strconv.Atoi(group.RUNNINGSTATUS)
+		return checkHyperMetro(rb.Kind, group.HEALTHSTATUS, dorado.HyperMetroPairState(status))
+	case TargetReplicationPair:
+		return r.checkReplication(ctx, rb.Kind, func(d *dorado.Device) (string, string, error) {
+			rp, err := d.GetReplicationPair(ctx, target.ID)
+			if err != nil {
+				return "", "", err
+			}
+			return rp.HEALTHSTATUS, rp.RUNNINGSTATUS, nil
+		})
+	case TargetReplicationConsistencyGroup:
+		return r.checkReplication(ctx, rb.Kind, func(d *dorado.Device) (string, string, error) {
+			group, err := d.GetReplicationConsistencyGroup(ctx, target.ID)
+			if err != nil {
+				return "", "", err
+			}
+			return group.HEALTHSTATUS, group.RUNNINGSTATUS, nil
+		})
+	}
+
+	return fmt.Errorf("unknown target: %s", target)
+}
+
+func checkHyperMetro(kind Kind, health string, state dorado.HyperMetroPairState) error {
+	switch kind {
+	case PlannedSwitchover:
+		if health != strconv.Itoa(dorado.StatusHealth) {
+			return fmt.Errorf("not healthy (HEALTHSTATUS: %s)", health)
+		}
+		if state != dorado.HyperMetroPairStateNormal {
+			return fmt.Errorf("must be normal, but %s", state)
+		}
+	case UnplannedFailover:
+		switch state {
+		case dorado.HyperMetroPairStatePause, dorado.HyperMetroPairStateToBeSynchronized, dorado.HyperMetroPairStateForcedStart:
+		default:
+			return fmt.Errorf("both sites may be running in %s, please use planned switchover", state)
+		}
+	case Failback:
+		switch state {
+		case dorado.HyperMetroPairStatePause, dorado.HyperMetroPairStateToBeSynchronized, dorado.HyperMetroPairStateForcedStart:
+		default:
+			return fmt.Errorf("nothing to fail back in %s", state)
+		}
+	}
+
+	return nil
+}
+
+func (r *Runner) checkReplication(ctx context.Context, kind Kind, get func(d *dorado.Device) (string, string, error)) error {
+	switch kind {
+	case PlannedSwitchover:
+		health, status, err := get(r.Replication.PrimaryDevice)
+		if err != nil {
+			return fmt.Errorf("failed to get from primary device: %w", err)
+		}
+		if health != strconv.Itoa(dorado.StatusHealth) {
+			return fmt.Errorf("not healthy (HEALTHSTATUS: %s)", health)
+		}
+		if status != strconv.Itoa(dorado.StatusReplicationNormal) {
+			return fmt.Errorf("must be normal, but RUNNINGSTATUS is %s", status)
+		}
+	case UnplannedFailover:
+		// primary device may be down, check only in secondary device.
+		if _, _, err := get(r.Replication.SecondaryDevice); err != nil {
+			return fmt.Errorf("failed to get from secondary device: %w", err)
+		}
+	case Failback:
+		_, status, err := get(r.Replication.SecondaryDevice)
+		if err != nil {
+			return fmt.Errorf("failed to get from secondary device: %w", err)
+		}
+		if status != strconv.Itoa(dorado.StatusReplicationSplit) {
+			return fmt.Errorf("must be split after failover, but RUNNINGSTATUS is %s", status)
+		}
+		if _, err := r.Replication.PrimaryDevice.GetSystem(ctx); err != nil {
+			return fmt.Errorf("primary device is not recovered: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Runner) steps(rb Runbook, target Target) []Step {
+	id := target.ID
+	step := func(name string, run func(ctx context.Context) error) Step {
+		return Step{Target: target, Name: name, run: run}
+	}
+
+	switch target.Kind {
+	case TargetHyperMetroPair:
+		c := r.HyperMetro
+		switch rb.Kind {
+		case PlannedSwitchover:
+			return []Step{step("switch priority", func(ctx context.Context) error {
+				return c.SwitchHyperMetroPairPriority(ctx, id)
+			})}
+		case UnplannedFailover:
+			return []Step{step("force start in local device", func(ctx context.Context) error {
+				return c.ForceStartHyperMetroPair(ctx, id)
+			})}
+		case Failback:
+			return []Step{step("sync and wait", func(ctx context.Context) error {
+				return c.SyncHyperMetroPairWithWait(ctx, id, rb.WaitSynced)
+			})}
+		}
+	case TargetHyperMetroConsistencyGroup:
+		c := r.HyperMetro
+		switch rb.Kind {
+		case PlannedSwitchover:
+			return []Step{step("switch priority", func(ctx context.Context) error {
+				return c.SwitchHyperMetroConsistencyGroupPriority(ctx, id)
+			})}
+		case UnplannedFailover:
+			return []Step{step("force start in local device", func(ctx context.Context) error {
+				return c.ForceStartHyperMetroConsistencyGroup(ctx, id)
+			})}
+		case Failback:
+			return []Step{step("sync and wait", func(ctx context.Context) error {
+				return c.SyncHyperMetroConsistencyGroupWithWait(ctx, id, rb.WaitSynced)
+			})}
+		}
+	case TargetReplicationPair:
+		c := r.Replication
+		switch rb.Kind {
+		case PlannedSwitchover:
+			return []Step{step("switchover", func(ctx context.Context) error {
+				return c.SwitchoverVolume(ctx, id)
+			})}
+		case UnplannedFailover:
+			return []Step{
+				step("split in secondary device", func(ctx context.Context) error {
+					rp, err := c.SecondaryDevice.GetReplicationPair(ctx, id)
+					if err != nil {
+						return err
+					}
+					if rp.RUNNINGSTATUS == strconv.Itoa(dorado.StatusReplicationSplit) {
+						return nil
+					}
+					return c.SecondaryDevice.SplitReplicationPair(ctx, id)
+				}),
+				step("enable write to secondary LUN", func(ctx context.Context) error {
+					return c.SecondaryDevice.SetReplicationPairSecondaryAccess(ctx, id, dorado.SecondaryAccessReadWrite)
+				}),
+			}
+		case Failback:
+			return []Step{
+				step("switch primary to secondary device", func(ctx context.Context) error {
+					primary, _, err := r.replicationDevices(ctx, target)
+					if err != nil {
+						return err
+					}
+					if primary == c.SecondaryDevice {
+						return nil // already switched
+					}
+					return primary.SwitchReplicationPair(ctx, id)
+				}),
+				step("sync from secondary device", func(ctx context.Context) error {
+					primary, _, err := r.replicationDevices(ctx, target)
+					if err != nil {
+						return err
+					}
+					return primary.SyncReplicationPair(ctx, id)
+				}),
+			}
+		}
+	case TargetReplicationConsistencyGroup:
+		c := r.Replication
+		switch rb.Kind {
+		case PlannedSwitchover:
+			return []Step{step("switchover", func(ctx context.Context) error {
+				return c.SwitchoverConsistencyGroup(ctx, id)
+			})}
+		case UnplannedFailover:
+			return []Step{
+				step("split in secondary device", func(ctx context.Context) error {
+					group, err := c.SecondaryDevice.GetReplicationConsistencyGroup(ctx, id)
+					if err != nil {
+						return err
+					}
+					if group.RUNNINGSTATUS == strconv.Itoa(dorado.StatusReplicationSplit) {
+						return nil
+					}
+					return c.SecondaryDevice.SplitReplicationConsistencyGroup(ctx, id)
+				}),
+				step("enable write to secondary LUNs", func(ctx context.Context) error {
+					return c.SecondaryDevice.SetReplicationConsistencyGroupSecondaryAccess(ctx, id, dorado.SecondaryAccessReadWrite)
+				}),
+			}
+		case Failback:
+			return []Step{
+				step("switch primary to secondary device", func(ctx context.Context) error {
+					primary, _, err := r.replicationDevices(ctx, target)
+					if err != nil {
+						return err
+					}
+					if primary == c.SecondaryDevice {
+						return nil // already switched
+					}
+					return primary.SwitchReplicationConsistencyGroup(ctx, id)
+				}),
+				step("sync from secondary device", func(ctx context.Context) error {
+					primary, _, err := r.replicationDevices(ctx, target)
+					if err != nil {
+						return err
+					}
+					return primary.SyncReplicationConsistencyGroup(ctx, id)
+				}),
+			}
+		}
+	}
+
+	return nil
+}
+
+// replicationDevices return devices that have primary and secondary of target now.
+func (r *Runner) replicationDevices(ctx context.Context, target Target) (primary, secondary *dorado.Device, err error) {
+	site, err := r.primarySite(ctx, target)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get primary site: %w", err)
+	}
+	if site == SiteSecondary {
+		return r.Replication.SecondaryDevice, r.Replication.PrimaryDevice, nil
+	}
+	return r.Replication.PrimaryDevice, r.Replication.SecondaryDevice, nil
+}
@@ -0,0 +1,288 @@
+package dr
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lovi-cloud/go-dorado-sdk/dorado"
+)
+
+// StepStatus is result status of step
+type StepStatus int
+
+// StepStatuses
+const (
+	StepNotRun StepStatus = iota
+	StepSkipped
+	StepSucceeded
+	StepFailed
+)
+
+// String return name of status
+func (s StepStatus) String() string {
+	switch s {
+	case StepNotRun:
+		return "not run"
+	case StepSkipped:
+		return "skipped"
+	case StepSucceeded:
+		return "succeeded"
+	case StepFailed:
+		return "failed"
+	default:
+		return fmt.Sprintf("unknown (%d)", int(s))
+	}
+}
+
+// StepResult is result of step
+type StepResult struct {
+	Target   Target
+	Name     string
+	Status   StepStatus
+	Err      error
+	Duration time.Duration
+}
+
+// StepError is error of failed step. errors.Is(err, ErrStepFailed) return true.
+type StepError struct {
+	Target Target
+	Name   string
+	Err    error
+}
+
+// Error return error message
+func (e *StepError) Error() string {
+	return fmt.Sprintf("%s: %s: %s: %s", ErrStepFailed, e.Target, e.Name, e.Err)
+}
+
+// Unwrap return error of step
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// Is return true if target is ErrStepFailed
+func (e *StepError) Is(target error) bool {
+	return target == ErrStepFailed
+}
+
+// Site is location of primary
+type Site string
+
+// Sites
+const (
+	// SiteLocal and SiteRemote are LocalDevice and RemoteDevice of dorado.Client.
+	SiteLocal  Site = "local"
+	SiteRemote Site = "remote"
+	// SitePrimary and SiteSecondary are PrimaryDevice and SecondaryDevice of dorado.ReplicationClient.
+	SitePrimary   Site = "primary"
+	SiteSecondary Site = "secondary"
+	SiteUnknown   Site = "unknown"
+)
+
+// VolumeRole is where is primary of target
+type VolumeRole struct {
+	Target Target
+	// PrimaryOn is site that serves writes. it is secondary site of replication
+	// after unplanned failover, even though role of primary is not changed.
+	PrimaryOn Site
+	// Err is set if failed to get role.
+	Err error
+}
+
+// Report is result of runbook
+type Report struct {
+	Runbook    Runbook
+	DryRun     bool
+	Checks     []Check
+	Steps      []StepResult
+	Volumes    []VolumeRole
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// String return readable report
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "runbook: %s (%s)", r.Runbook.Name, r.Runbook.Kind)
+	if r.DryRun {
+		b.WriteString(" [dry-run]")
+	}
+	b.WriteString("\nsteps:\n")
+	for i, s := range r.Steps {
+		fmt.Fprintf(&b, "  %d. %s: %s: %s", i+1, s.Target, s.Name, s.Status)
+		if s.Err != nil {
+			fmt.Fprintf(&b, " (%s)", s.Err)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("volumes:\n")
+	for _, v := range r.Volumes {
+		if v.Err != nil {
+			fmt.Fprintf(&b, "  %s: %s (%s)\n", v.Target, v.PrimaryOn, v.Err)
+			continue
+		}
+		fmt.Fprintf(&b, "  %s: primary on %s\n", v.Target, v.PrimaryOn)
+	}
+
+	return b.String()
+}
+
+// Run run runbook. if dryRun is true, only run pre-flight checks and report plan.
+// return report with ErrPreflightFailed or ErrStepFailed if runbook is not completed.
+func (r *Runner) Run(ctx context.Context, rb Runbook, dryRun bool) (*Report, error) {
+	report := &Report{
+		Runbook:   rb,
+		DryRun:    dryRun,
+		StartedAt: time.Now(),
+	}
+	defer func() {
+		report.FinishedAt = time.Now()
+	}()
+
+	plan, err := r.Plan(ctx, rb)
+	if err != nil {
+		return nil, err
+	}
+	report.Checks = plan.Checks
+	for _, s := range plan.Steps {
+		report.Steps = append(report.Steps, StepResult{Target: s.Target, Name: s.Name})
+	}
+
+	for _, c := range plan.Checks {
+		if c.Err != nil {
+			r.Logger.Printf("[%s] pre-flight check failed %s: %s", rb.Name, c.Target, c.Err)
+		}
+	}
+	if !plan.Ready() {
+		report.Volumes = r.volumeRoles(ctx, rb)
+		return report, ErrPreflightFailed
+	}
+
+	if dryRun {
+		for i := range report.Steps {
+			report.Steps[i].Status = StepSkipped
+		}
+		report.Volumes = r.volumeRoles(ctx, rb)
+		return report, nil
+	}
+
+	for i, s := range plan.Steps {
+		r.Logger.Printf("[%s] step %d/%d start: %s: %s", rb.Name, i+1, len(plan.Steps), s.Target, s.Name)
+		start := time.Now()
+		err := s.run(ctx)
+		report.Steps[i].Duration = time.Since(start)
+		if err != nil {
+			r.Logger.Printf("[%s] step %d/%d failed: %s: %s: %s", rb.Name, i+1, len(plan.Steps), s.Target, s.Name, err)
+			report.Steps[i].Status = StepFailed
+			report.Steps[i].Err = err
+			report.Volumes = r.volumeRoles(ctx, rb)
+			return report, &StepError{Target: s.Target, Name: s.Name, Err: err}
+		}
+		r.Logger.Printf("[%s] step %d/%d done: %s: %s", rb.Name, i+1, len(plan.Steps), s.Target, s.Name)
+		report.Steps[i].Status = StepSucceeded
+	}
+
+	report.Volumes = r.volumeRoles(ctx, rb)
+	return report, nil
+}
+
+func (r *Runner) volumeRoles(ctx context.Context, rb Runbook) []VolumeRole {
+	var roles []VolumeRole
+	for _, target := range rb.targets() {
+		site, err := r.writableSite(ctx, target)
+		roles = append(roles, VolumeRole{
+			Target:    target,
+			PrimaryOn: site,
+			Err:       err,
+		})
+	}
+
+	return roles
+}
+
+// primarySite return site that has role of primary.
+func (r *Runner) primarySite(ctx context.Context, target Target) (Site, error) {
+	return r.site(ctx, target, false)
+}
+
+// writableSite return site that serves writes.
+// it is different from primarySite if secondary of replication is writable by unplanned failover.
+func (r *Runner) writableSite(ctx context.Context, target Target) (Site, error) {
+	return r.site(ctx, target, true)
+}
+
+func (r *Runner) site(ctx context.Context, target Target, writable bool) (Site, error) {
+	switch target.Kind {
+	case TargetHyperMetroPair:
+		hmp, err := r.HyperMetro.GetHyperMetroPair(ctx, target.ID)
+		if err != nil {
+			return SiteUnknown, err
+		}
+		return hyperMetroSite(hmp.ISPRIMARY), nil
+	case TargetHyperMetroConsistencyGroup:
+		group, err := r.HyperMetro.GetHyperMetroConsistencyGroup(ctx, target.ID)
+		if err != nil {
+			return SiteUnknown, err
+		}
+		return hyperMetroSite(group.ISPRIMARY), nil
+	case TargetReplicationPair:
+		return r.replicationSite(writable, func(d *dorado.Device) (string, string, error) {
+			rp, err := d.GetReplicationPair(ctx, target.ID)
+			if err != nil {
+				return "", "", err
+			}
+			return rp.ISPRIMARY, rp.SECRESACCESS, nil
+		})
+	case TargetReplicationConsistencyGroup:
+		return r.replicationSite(writable, func(d *dorado.Device) (string, string, error) {
+			group, err := d.GetReplicationConsistencyGroup(ctx, target.ID)
+			if err != nil {
+				return "", "", err
+			}
+			return group.ISPRIMARY, group.SECRESACCESS, nil
+		})
+	}
+
+	return SiteUnknown, fmt.Errorf("unknown target: %s", target)
+}
+
+func hyperMetroSite(isPrimary string) Site {
+	// ISPRIMARY is a value in LocalDevice
+	if isPrimary == "true" {
+		return SiteLocal
+	}
+	return SiteRemote
+}
+
+func (r *Runner) replicationSite(writable bool, getRole func(d *dorado.Device) (isPrimary, secResAccess string, err error)) (Site, error) {
+	// primary device may be down, so fallback to secondary device.
+	isPrimary, secResAccess, err := getRole(r.Replication.PrimaryDevice)
+	if err != nil {
+		var serr error
+		isPrimary, secResAccess, serr = getRole(r.Replication.SecondaryDevice)
+		if serr != nil {
+			return SiteUnknown, fmt.Errorf("failed to get from both devices: %s, %w", err, serr)
+		}
+		isPrimary = flipBoolString(isPrimary)
+	}
+
+	primary, secondary := SitePrimary, SiteSecondary
+	if isPrimary != "true" {
+		primary, secondary = secondary, primary
+	}
+	if writable && secResAccess == strconv.Itoa(dorado.SecondaryAccessReadWrite) {
+		// secondary serves writes after unplanned failover
+		return secondary, nil
+	}
+	return primary, nil
+}
+
+func flipBoolString(s string) string {
+	if s == "true" {
+		return "false"
+	}
+	return "true"
+}
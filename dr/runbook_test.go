@@ -0,0 +1,215 @@
+package dr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/lovi-cloud/go-dorado-sdk/dorado"
+)
+
+func setup(t *testing.T) (*dorado.Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	apiHandler := http.NewServeMux()
+	apiHandler.Handle("/deviceManager/rest/xx/", http.StripPrefix("/deviceManager/rest/xx", mux))
+	server := httptest.NewServer(apiHandler)
+
+	client, err := dorado.NewClientDefaultToken([]string{server.URL}, []string{server.URL}, "username", "password", "portgroup", nil)
+	if err != nil {
+		t.Fatalf("failed to create dorado.Client: %s", err)
+	}
+	u, err := url.Parse(server.URL + "/deviceManager/rest/xx")
+	if err != nil {
+		t.Fatalf("failed to parse url: %s", err)
+	}
+	client.LocalDevice.URL = u
+	client.RemoteDevice.URL = u
+
+	return client, mux, server.Close
+}
+
+func TestRunner_Run_DryRun(t *testing.T) {
+	client, mux, teardown := setup(t)
+	defer teardown()
+
+	mux.HandleFunc("/HyperMetroPair/e4c2d1eaf02c0001", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("dry-run must not change objects, but %s %s", r.Method, r.URL.Path)
+		}
+		fmt.Fprint(w, `{"data": {"HEALTHSTATUS": "1", "ID": "e4c2d1eaf02c0001", "ISPRIMARY": "true", "RUNNINGSTATUS": "1", "TYPE": 15361}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	runner := NewRunner(client, nil, nil)
+	report, err := runner.Run(context.Background(), Runbook{
+		Name:              "test",
+		Kind:              PlannedSwitchover,
+		HyperMetroPairIDs: []string{"e4c2d1eaf02c0001"},
+	}, true)
+	if err != nil {
+		t.Fatalf("Run return err: %s", err)
+	}
+
+	if len(report.Steps) != 1 || report.Steps[0].Name != "switch priority" || report.Steps[0].Status != StepSkipped {
+		t.Errorf("Run return invalid steps: %+v", report.Steps)
+	}
+	if len(report.Volumes) != 1 || report.Volumes[0].PrimaryOn != SiteLocal {
+		t.Errorf("Run return invalid volumes: %+v", report.Volumes)
+	}
+}
+
+func TestRunner_Run_PreflightFailed(t *testing.T) {
+	client, mux, teardown := setup(t)
+	defer teardown()
+
+	mux.HandleFunc("/HyperMetroPair/e4c2d1eaf02c0001", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("pre-flight failed runbook must not change objects, but %s %s", r.Method, r.URL.Path)
+		}
+		fmt.Fprint(w, `{"data": {"HEALTHSTATUS": "1", "ID": "e4c2d1eaf02c0001", "ISPRIMARY": "true", "RUNNINGSTATUS": "1", "TYPE": 15361}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	runner := NewRunner(client, nil, nil)
+	report, err := runner.Run(context.Background(), Runbook{
+		Name:              "test",
+		Kind:              UnplannedFailover,
+		HyperMetroPairIDs: []string{"e4c2d1eaf02c0001"},
+	}, false)
+	if !errors.Is(err, ErrPreflightFailed) {
+		t.Fatalf("Run return err: %v, want %v", err, ErrPreflightFailed)
+	}
+	if len(report.Checks) != 1 || report.Checks[0].Err == nil {
+		t.Errorf("Run return invalid checks: %+v", report.Checks)
+	}
+	if report.Steps[0].Status != StepNotRun {
+		t.Errorf("Run return step status %s, want %s", report.Steps[0].Status, StepNotRun)
+	}
+}
+
+func TestRunner_Plan_ClientRequired(t *testing.T) {
+	runner := NewRunner(nil, nil, nil)
+	_, err := runner.Plan(context.Background(), Runbook{
+		Kind:               PlannedSwitchover,
+		ReplicationPairIDs: []string{"1"},
+	})
+	if !errors.Is(err, ErrClientRequired) {
+		t.Errorf("Plan return err: %v, want %v", err, ErrClientRequired)
+	}
+}
+
+func TestRunner_Run_ReplicationFailback(t *testing.T) {
+	client, mux, teardown := setup(t)
+	defer teardown()
+
+	remoteMux := http.NewServeMux()
+	apiHandler := http.NewServeMux()
+	apiHandler.Handle("/deviceManager/rest/xx/", http.StripPrefix("/deviceManager/rest/xx", remoteMux))
+	server := httptest.NewServer(apiHandler)
+	defer server.Close()
+	u, err := url.Parse(server.URL + "/deviceManager/rest/xx")
+	if err != nil {
+		t.Fatalf("failed to parse url: %s", err)
+	}
+	client.RemoteDevice.URL = u
+
+	switched := false
+	var calls []string
+	pair := func(isPrimary bool) string {
+		return fmt.Sprintf(`{"data": {"HEALTHSTATUS": "1", "ID": "4b6a4d1bf2b40000", "ISPRIMARY": "%t", "RUNNINGSTATUS": "26", "TYPE": 263}, "error": {"code": 0, "description": "0"}}`, isPrimary)
+	}
+	mux.HandleFunc("/REPLICATIONPAIR/4b6a4d1bf2b40000", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, pair(!switched))
+	})
+	mux.HandleFunc("/REPLICATIONPAIR/", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "primary "+r.URL.Path)
+		switched = switched || r.URL.Path == "/REPLICATIONPAIR/switch"
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	})
+	mux.HandleFunc("/system/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": {"ID": "2102351NPT10J3000001"}, "error": {"code": 0, "description": "0"}}`)
+	})
+	remoteMux.HandleFunc("/REPLICATIONPAIR/4b6a4d1bf2b40000", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, pair(switched))
+	})
+	remoteMux.HandleFunc("/REPLICATIONPAIR/", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "secondary "+r.URL.Path)
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	replication := &dorado.ReplicationClient{PrimaryDevice: client.LocalDevice, SecondaryDevice: client.RemoteDevice}
+	runner := NewRunner(nil, replication, nil)
+	_, err = runner.Run(context.Background(), Runbook{
+		Name:               "test",
+		Kind:               Failback,
+		ReplicationPairIDs: []string{"4b6a4d1bf2b40000"},
+	}, false)
+	if err != nil {
+		t.Fatalf("Run return err: %s", err)
+	}
+
+	want := []string{"primary /REPLICATIONPAIR/switch", "secondary /REPLICATIONPAIR/sync"}
+	if fmt.Sprint(calls) != fmt.Sprint(want) {
+		t.Errorf("Run call %v, want %v", calls, want)
+	}
+}
+
+func TestRunner_Run_ReplicationUnplannedFailover(t *testing.T) {
+	client, mux, teardown := setup(t)
+	defer teardown()
+
+	remoteMux := http.NewServeMux()
+	apiHandler := http.NewServeMux()
+	apiHandler.Handle("/deviceManager/rest/xx/", http.StripPrefix("/deviceManager/rest/xx", remoteMux))
+	server := httptest.NewServer(apiHandler)
+	defer server.Close()
+	u, err := url.Parse(server.URL + "/deviceManager/rest/xx")
+	if err != nil {
+		t.Fatalf("failed to parse url: %s", err)
+	}
+	client.RemoteDevice.URL = u
+
+	writable := false
+	pair := func(isPrimary bool) string {
+		access := dorado.SecondaryAccessReadOnly
+		status := "1"
+		if writable {
+			access = dorado.SecondaryAccessReadWrite
+			status = "26"
+		}
+		return fmt.Sprintf(`{"data": {"HEALTHSTATUS": "1", "ID": "4b6a4d1bf2b40000", "ISPRIMARY": "%t", "RUNNINGSTATUS": "%s", "SECRESACCESS": "%d", "TYPE": 263}, "error": {"code": 0, "description": "0"}}`, isPrimary, status, access)
+	}
+	mux.HandleFunc("/REPLICATIONPAIR/4b6a4d1bf2b40000", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, pair(true))
+	})
+	mux.HandleFunc("/system/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": {"ID": "2102351NPT10J3000001"}, "error": {"code": 0, "description": "0"}}`)
+	})
+	remoteMux.HandleFunc("/REPLICATIONPAIR/4b6a4d1bf2b40000", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, pair(false))
+	})
+	remoteMux.HandleFunc("/REPLICATIONPAIR/", func(w http.ResponseWriter, r *http.Request) {
+		writable = writable || r.URL.Path == "/REPLICATIONPAIR/CANCEL_SECODARY_WRITE_LOCK"
+		fmt.Fprint(w, `{"data": {}, "error": {"code": 0, "description": "0"}}`)
+	})
+	remoteMux.HandleFunc("/system/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": {"ID": "2102351NPT10J3000002"}, "error": {"code": 0, "description": "0"}}`)
+	})
+
+	replication := &dorado.ReplicationClient{PrimaryDevice: client.LocalDevice, SecondaryDevice: client.RemoteDevice}
+	runner := NewRunner(nil, replication, nil)
+	report, err := runner.Run(context.Background(), Runbook{
+		Name:               "test",
+		Kind:               UnplannedFailover,
+		ReplicationPairIDs: []string{"4b6a4d1bf2b40000"},
+	}, false)
+	if err != nil {
+		t.Fatalf("Run return err: %s", err)
+	}
+
+	if len(report.Volumes) != 1 || report.Volumes[0].PrimaryOn != SiteSecondary {
+		t.Errorf("Run report volumes %+v, want primary on %s", report.Volumes, SiteSecondary)
+	}
+}
@@ -167,14 +167,14 @@ func attachVolume(client *dorado.Client, ctx context.Context) error {
 	}
 
 	fmt.Println("create volume")
-	volume, err := client.CreateVolumeRaw(ctx, u, 21, lib.StoragePoolName, hgs[0].ID)
+	volume, err := client.CreateVolumeRaw(ctx, u, 21, lib.StoragePoolName, hgs[0].ID, nil)
 	if err != nil {
 		return err
 	}
 	fmt.Printf("%+v\n", volume)
 
 	fmt.Println("attach volume")
-	err = client.AttachVolume(ctx, volume.ID, "w-cn0001", "dummy-iqn")
+	_, err = client.AttachVolume(ctx, volume.ID, "w-cn0001", "dummy-iqn", nil)
 	if err != nil {
 		return err
 	}
@@ -193,14 +193,14 @@ func volumeOperation(client *dorado.Client, ctx context.Context) error {
 	}
 
 	fmt.Println("create volume")
-	volume, err := client.CreateVolumeRaw(ctx, u, 21, lib.StoragePoolName, hgs[0].ID)
+	volume, err := client.CreateVolumeRaw(ctx, u, 21, lib.StoragePoolName, hgs[0].ID, nil)
 	if err != nil {
 		return err
 	}
 	fmt.Printf("%+v\n", volume)
 
 	fmt.Println("attach volume")
-	err = client.AttachVolume(ctx, volume.ID, "w-cn0001", "dummy-iqn")
+	_, err = client.AttachVolume(ctx, volume.ID, "w-cn0001", "dummy-iqn", nil)
 	if err != nil {
 		return err
 	}
@@ -223,7 +223,7 @@ func singleLunOperation(client *dorado.Client, ctx context.Context) error {
 		return err
 	}
 
-	hmp, err := client.CreateVolumeRaw(ctx, u, 21, lib.StoragePoolName, hgs[0].ID)
+	hmp, err := client.CreateVolumeRaw(ctx, u, 21, lib.StoragePoolName, hgs[0].ID, nil)
 	if err != nil {
 		return err
 	}
@@ -231,7 +231,7 @@ func singleLunOperation(client *dorado.Client, ctx context.Context) error {
 	fmt.Printf("%+v\n", hmp)
 
 	fmt.Println("expand volume")
-	err = client.ExtendVolume(ctx, hmp.ID, 30)
+	err = client.ExtendVolume(ctx, hmp.ID, 30, nil)
 	if err != nil {
 		return err
 	}
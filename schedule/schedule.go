@@ -0,0 +1,255 @@
+// Package schedule provides client-side snapshot schedules and retention policies.
+// state of schedule is not stored in client, it is read back from snapshot names in device.
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lovi-cloud/go-dorado-sdk/dorado"
+)
+
+// Error Values
+var (
+	ErrInvalidPolicy = errors.New("policy is invalid")
+	ErrNameTooLong   = errors.New("snapshot name is too long")
+)
+
+// Clock is source of current time
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// SystemClock is Clock that return current time of system
+var SystemClock Clock = systemClock{}
+
+// Policy is snapshot schedule and retention.
+// a snapshot is taken once per Interval (aligned to Unix epoch), and newest Keep snapshots are retained.
+type Policy struct {
+	// Tag is one lowercase letter to identify policy in snapshot name.
+	Tag      string
+	Interval time.Duration
+	Keep     int
+}
+
+// Hourly return Policy that take snapshot per hour
+func Hourly(keep int) Policy {
+	return Policy{Tag: "h", Interval: time.Hour, Keep: keep}
+}
+
+// Daily return Policy that take snapshot per day
+func Daily(keep int) Policy {
+	return Policy{Tag: "d", Interval: 24 * time.Hour, Keep: keep}
+}
+
+// Weekly return Policy that take snapshot per week
+func Weekly(keep int) Policy {
+	return Policy{Tag: "w", Interval: 7 * 24 * time.Hour, Keep: keep}
+}
+
+// Validate check value of Policy
+func (p Policy) Validate() error {
+	if len(p.Tag) != 1 || p.Tag[0] < 'a' || p.Tag[0] > 'z' {
+		return fmt.Errorf("tag must be one lowercase letter (tag: %q): %w", p.Tag, ErrInvalidPolicy)
+	}
+	if p.Interval < time.Minute {
+		return fmt.Errorf("interval must be one minute or more (interval: %s): %w", p.Interval, ErrInvalidPolicy)
+	}
+	if p.Keep < 1 {
+		return fmt.Errorf("keep must be one or more (keep: %d): %w", p.Keep, ErrInvalidPolicy)
+	}
+
+	return nil
+}
+
+// slot return number of Interval since Unix epoch at t
+func (p Policy) slot(t time.Time) int64 {
+	return t.Unix() / int64(p.Interval/time.Second)
+}
+
+// SnapshotName return name of snapshot that taken by policy at t.
+// format is "s<key>-<tag><unix time in base36>", ex: "se4c2d1eaf02c0001-hqzkfsw".
+func SnapshotName(key string, p Policy, t time.Time) (string, error) {
+	name := fmt.Sprintf("s%s-%s%s", key, p.Tag, strconv.FormatInt(t.Unix(), 36))
+	if len(name) > dorado.MaxNameLength {
+		return "", fmt.Errorf("%s (max: %d): %w", name, dorado.MaxNameLength, ErrNameTooLong)
+	}
+
+	return name, nil
+}
+
+// ParseSnapshotName parse name of snapshot that taken by schedule.
+// return false if name is not taken by schedule of key.
+func ParseSnapshotName(key, name string) (tag string, t time.Time, ok bool) {
+	prefix := "s" + key + "-"
+	if !strings.HasPrefix(name, prefix) || len(name) < len(prefix)+2 {
+		return "", time.Time{}, false
+	}
+	rest := name[len(prefix):]
+
+	unix, err := strconv.ParseInt(rest[1:], 36, 64)
+	if err != nil {
+		return "", time.Time{}, false
+	}
+
+	return rest[:1], time.Unix(unix, 0), true
+}
+
+// Target is object that snapshots are taken by schedule
+type Target interface {
+	// Key is unique key of target in device, used in snapshot name.
+	Key() string
+	// ListSnapshots return names of snapshots of target.
+	ListSnapshots(ctx context.Context) ([]string, error)
+	// CreateSnapshot create and activate snapshot of target.
+	CreateSnapshot(ctx context.Context, name string) error
+	// DeleteSnapshot stop and delete snapshot of target.
+	DeleteSnapshot(ctx context.Context, name string) error
+}
+
+// Scheduler run policies against targets
+type Scheduler struct {
+	Policies []Policy
+	Clock    Clock
+	Logger   *log.Logger
+}
+
+// New create Scheduler. clock is SystemClock if nil.
+func New(policies []Policy, clock Clock, logger *log.Logger) (*Scheduler, error) {
+	tags := map[string]bool{}
+	for _, p := range policies {
+		if err := p.Validate(); err != nil {
+			return nil, err
+		}
+		if tags[p.Tag] {
+			return nil, fmt.Errorf("tag %q is duplicated: %w", p.Tag, ErrInvalidPolicy)
+		}
+		tags[p.Tag] = true
+	}
+
+	if clock == nil {
+		clock = SystemClock
+	}
+	if logger == nil {
+		logger = log.New(ioutil.Discard, "", log.LstdFlags)
+	}
+
+	return &Scheduler{
+		Policies: policies,
+		Clock:    clock,
+		Logger:   logger,
+	}, nil
+}
+
+// Result is result of RunOnce
+type Result struct {
+	Created []string
+	Deleted []string
+	Errors  []error
+}
+
+// RunOnce take snapshots that are due and delete snapshots that are expired in all targets.
+// a failed target does not stop other targets, errors are returned in Result.Errors.
+func (s *Scheduler) RunOnce(ctx context.Context, targets []Target) (*Result, error) {
+	result := &Result{}
+	now := s.Clock.Now()
+
+	for _, target := range targets {
+		if err := s.runTarget(ctx, target, now, result); err != nil {
+			s.Logger.Printf("failed to run schedule of %s: %v", target.Key(), err)
+			result.Errors = append(result.Errors, fmt.Errorf("failed to run schedule of %s: %w", target.Key(), err))
+		}
+	}
+
+	if len(result.Errors) != 0 {
+		return result, fmt.Errorf("failed to run schedule in %d targets: %w", len(result.Errors), result.Errors[0])
+	}
+
+	return result, nil
+}
+
+// Run call RunOnce per interval until ctx is done.
+func (s *Scheduler) Run(ctx context.Context, targets []Target, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.RunOnce(ctx, targets); err != nil {
+			s.Logger.Printf("failed to run schedule: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) runTarget(ctx context.Context, target Target, now time.Time, result *Result) error {
+	names, err := target.ListSnapshots(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	// snapshots taken by schedule, newest first
+	taken := map[string][]scheduledSnapshot{}
+	for _, name := range names {
+		tag, t, ok := ParseSnapshotName(target.Key(), name)
+		if !ok {
+			continue
+		}
+		taken[tag] = append(taken[tag], scheduledSnapshot{name: name, time: t})
+	}
+	for tag := range taken {
+		sort.Slice(taken[tag], func(i, j int) bool {
+			return taken[tag][i].time.After(taken[tag][j].time)
+		})
+	}
+
+	for _, p := range s.Policies {
+		snapshots := taken[p.Tag]
+
+		if len(snapshots) == 0 || p.slot(now) > p.slot(snapshots[0].time) {
+			name, err := SnapshotName(target.Key(), p, now)
+			if err != nil {
+				return err
+			}
+			if err := target.CreateSnapshot(ctx, name); err != nil {
+				return fmt.Errorf("failed to create snapshot %s: %w", name, err)
+			}
+			result.Created = append(result.Created, name)
+			snapshots = append([]scheduledSnapshot{{name: name, time: now}}, snapshots...)
+		}
+
+		if len(snapshots) <= p.Keep {
+			continue
+		}
+		for _, expired := range snapshots[p.Keep:] {
+			if err := target.DeleteSnapshot(ctx, expired.name); err != nil {
+				return fmt.Errorf("failed to delete snapshot %s: %w", expired.name, err)
+			}
+			result.Deleted = append(result.Deleted, expired.name)
+		}
+	}
+
+	return nil
+}
+
+type scheduledSnapshot struct {
+	name string
+	time time.Time
+}
@@ -0,0 +1,203 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+type fakeTarget struct {
+	snapshots map[string]bool
+}
+
+func (t *fakeTarget) Key() string {
+	return "l7"
+}
+
+func (t *fakeTarget) ListSnapshots(ctx context.Context) ([]string, error) {
+	var names []string
+	for name := range t.snapshots {
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func (t *fakeTarget) CreateSnapshot(ctx context.Context, name string) error {
+	t.snapshots[name] = true
+	return nil
+}
+
+func (t *fakeTarget) DeleteSnapshot(ctx context.Context, name string) error {
+	delete(t.snapshots, name)
+	return nil
+}
+
+func (t *fakeTarget) count(tag string) int {
+	n := 0
+	for name := range t.snapshots {
+		if got, _, ok := ParseSnapshotName(t.Key(), name); ok && got == tag {
+			n++
+		}
+	}
+	return n
+}
+
+func TestSnapshotName(t *testing.T) {
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	name, err := SnapshotName("e4c2d1eaf02c0001", Hourly(24), now)
+	if err != nil {
+		t.Fatalf("SnapshotName return err: %s", err)
+	}
+	if len(name) > 31 {
+		t.Errorf("SnapshotName return %s, longer than MaxNameLength", name)
+	}
+
+	tag, got, ok := ParseSnapshotName("e4c2d1eaf02c0001", name)
+	if !ok || tag != "h" || !got.Equal(now) {
+		t.Errorf("ParseSnapshotName(%s) return (%s, %s, %v), want (h, %s, true)", name, tag, got, ok, now)
+	}
+
+	if _, _, ok := ParseSnapshotName("e4c2d1eaf02c0001", "manual-snapshot"); ok {
+		t.Errorf("ParseSnapshotName must ignore snapshot that not taken by schedule")
+	}
+
+	_, err = SnapshotName("0123456789abcdef0123456789", Hourly(24), now)
+	if !errors.Is(err, ErrNameTooLong) {
+		t.Errorf("SnapshotName return err: %v, want %v", err, ErrNameTooLong)
+	}
+}
+
+func TestScheduler_RunOnce(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2020, 10, 1, 0, 30, 0, 0, time.UTC)}
+	s, err := New([]Policy{Hourly(3), Daily(2)}, clock, nil)
+	if err != nil {
+		t.Fatalf("New return err: %s", err)
+	}
+	target := &fakeTarget{snapshots: map[string]bool{"manual-snapshot": true}}
+
+	// run every 30 minutes for 3 days
+	for i := 0; i < 2*24*3; i++ {
+		if _, err := s.RunOnce(context.Background(), []Target{target}); err != nil {
+			t.Fatalf("RunOnce return err: %s", err)
+		}
+		clock.now = clock.now.Add(30 * time.Minute)
+	}
+
+	if got := target.count("h"); got != 3 {
+		t.Errorf("hourly snapshots = %d, want 3", got)
+	}
+	if got := target.count("d"); got != 2 {
+		t.Errorf("daily snapshots = %d, want 2", got)
+	}
+	if !target.snapshots["manual-snapshot"] {
+		t.Errorf("snapshot that not taken by schedule must not be deleted")
+	}
+}
+
+func TestScheduler_RunOnce_Restart(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2020, 10, 1, 10, 10, 0, 0, time.UTC)}
+	target := &fakeTarget{snapshots: map[string]bool{}}
+
+	s, err := New([]Policy{Hourly(24)}, clock, nil)
+	if err != nil {
+		t.Fatalf("New return err: %s", err)
+	}
+	first, err := s.RunOnce(context.Background(), []Target{target})
+	if err != nil {
+		t.Fatalf("RunOnce return err: %s", err)
+	}
+
+	// new Scheduler read back snapshots from target, not take again in same hour
+	clock.now = clock.now.Add(40 * time.Minute)
+	s, err = New([]Policy{Hourly(24)}, clock, nil)
+	if err != nil {
+		t.Fatalf("New return err: %s", err)
+	}
+	second, err := s.RunOnce(context.Background(), []Target{target})
+	if err != nil {
+		t.Fatalf("RunOnce return err: %s", err)
+	}
+
+	if len(first.Created) != 1 || len(second.Created) != 0 {
+		t.Errorf("RunOnce created %v and %v, want one snapshot in first run only", first.Created, second.Created)
+	}
+}
+
+func TestScheduler_RunOnce_EpochAligned(t *testing.T) {
+	// Unix epoch is Thursday, so weekly slot starts at Thursday 00:00 UTC
+	clock := &fakeClock{now: time.Date(2020, 10, 7, 23, 0, 0, 0, time.UTC)}
+	target := &fakeTarget{snapshots: map[string]bool{}}
+
+	s, err := New([]Policy{Weekly(4)}, clock, nil)
+	if err != nil {
+		t.Fatalf("New return err: %s", err)
+	}
+	if _, err := s.RunOnce(context.Background(), []Target{target}); err != nil {
+		t.Fatalf("RunOnce return err: %s", err)
+	}
+
+	clock.now = time.Date(2020, 10, 8, 0, 30, 0, 0, time.UTC)
+	result, err := s.RunOnce(context.Background(), []Target{target})
+	if err != nil {
+		t.Fatalf("RunOnce return err: %s", err)
+	}
+	if len(result.Created) != 1 {
+		t.Errorf("RunOnce created %v, want new snapshot in next weekly slot", result.Created)
+	}
+}
+
+func TestNew_InvalidPolicy(t *testing.T) {
+	tests := [][]Policy{
+		{{Tag: "", Interval: time.Hour, Keep: 1}},
+		{{Tag: "h", Interval: 0, Keep: 1}},
+		{{Tag: "h", Interval: time.Hour, Keep: 0}},
+		{Hourly(1), {Tag: "h", Interval: 2 * time.Hour, Keep: 1}},
+	}
+
+	for _, policies := range tests {
+		if _, err := New(policies, nil, nil); !errors.Is(err, ErrInvalidPolicy) {
+			t.Errorf("New(%+v) return err: %v, want %v", policies, err, ErrInvalidPolicy)
+		}
+	}
+}
+
+func TestScheduler_RunOnce_Prune(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)}
+	target := &fakeTarget{snapshots: map[string]bool{}}
+	for i := 1; i <= 3; i++ {
+		name, _ := SnapshotName(target.Key(), Daily(1), clock.now.Add(-time.Duration(i)*24*time.Hour))
+		target.snapshots[name] = true
+	}
+
+	s, err := New([]Policy{Daily(2)}, clock, nil)
+	if err != nil {
+		t.Fatalf("New return err: %s", err)
+	}
+	result, err := s.RunOnce(context.Background(), []Target{target})
+	if err != nil {
+		t.Fatalf("RunOnce return err: %s", err)
+	}
+
+	var want []string
+	for i := 2; i <= 3; i++ {
+		name, _ := SnapshotName(target.Key(), Daily(1), clock.now.Add(-time.Duration(i)*24*time.Hour))
+		want = append(want, name)
+	}
+	sort.Strings(result.Deleted)
+	sort.Strings(want)
+	if !reflect.DeepEqual(result.Deleted, want) {
+		t.Errorf("RunOnce deleted %v, want %v", result.Deleted, want)
+	}
+}
@@ -0,0 +1,119 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/lovi-cloud/go-dorado-sdk/dorado"
+)
+
+// LUN is Target of LUN in a device
+type LUN struct {
+	Device *dorado.Device
+	LUNID  int
+}
+
+// Key return key of LUN
+func (l *LUN) Key() string {
+	return "l" + strconv.Itoa(l.LUNID)
+}
+
+// ListSnapshots return names of snapshots of LUN
+func (l *LUN) ListSnapshots(ctx context.Context) ([]string, error) {
+	snapshots, err := l.Device.GetSnapshots(ctx, &dorado.SearchQuery{
+		Filter: dorado.ToFilter("PARENTID", strconv.Itoa(l.LUNID)),
+	})
+	if err == dorado.ErrSnapshotNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, snapshot := range snapshots {
+		if snapshot.PARENTTYPE != dorado.TypeLUN {
+			continue
+		}
+		names = append(names, snapshot.NAME)
+	}
+
+	return names, nil
+}
+
+// CreateSnapshot create and activate snapshot of LUN
+func (l *LUN) CreateSnapshot(ctx context.Context, name string) error {
+	snapshot, err := l.Device.CreateSnapshotByNameWithWait(ctx, l.LUNID, name, "scheduled snapshot")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot: %w", err)
+	}
+
+	if err := l.Device.ActivateSnapshot(ctx, snapshot.ID); err != nil {
+		return fmt.Errorf("failed to activate snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSnapshot stop and delete snapshot of LUN
+func (l *LUN) DeleteSnapshot(ctx context.Context, name string) error {
+	snapshots, err := l.Device.GetSnapshots(ctx, dorado.NewSearchQueryName(name))
+	if err != nil {
+		return fmt.Errorf("failed to get snapshot: %w", err)
+	}
+
+	for _, snapshot := range snapshots {
+		if snapshot.NAME != name || snapshot.PARENTID != l.LUNID {
+			continue
+		}
+
+		if err := l.Device.StopAndDeleteSnapshot(ctx, &snapshot); err != nil {
+			return fmt.Errorf("failed to delete snapshot: %w", err)
+		}
+		return nil
+	}
+
+	return dorado.ErrSnapshotNotFound
+}
+
+// Volume is Target of volume (= HyperMetroPair).
+// snapshots are taken by VolumeSnapshotMode of Client.
+type Volume struct {
+	Client           *dorado.Client
+	HyperMetroPairID string
+}
+
+// Key return key of volume
+func (v *Volume) Key() string {
+	return v.HyperMetroPairID
+}
+
+// ListSnapshots return names of snapshots of volume
+func (v *Volume) ListSnapshots(ctx context.Context) ([]string, error) {
+	volumeSnapshots, err := v.Client.ListVolumeSnapshots(ctx, v.HyperMetroPairID)
+	if err == dorado.ErrSnapshotNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, vs := range volumeSnapshots {
+		names = append(names, vs.Name)
+	}
+
+	return names, nil
+}
+
+// CreateSnapshot create and activate snapshots of volume
+func (v *Volume) CreateSnapshot(ctx context.Context, name string) error {
+	_, err := v.Client.CreateVolumeSnapshotByName(ctx, v.HyperMetroPairID, name)
+	return err
+}
+
+// DeleteSnapshot stop and delete snapshots of volume
+func (v *Volume) DeleteSnapshot(ctx context.Context, name string) error {
+	return v.Client.DeleteVolumeSnapshotByName(ctx, v.HyperMetroPairID, name)
+}